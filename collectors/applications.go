@@ -22,17 +22,21 @@ type ApplicationsCollector struct {
 	applicationInstancesRunningMetric           *prometheus.GaugeVec
 	applicationMemoryMbMetric                   *prometheus.GaugeVec
 	applicationDiskQuotaMbMetric                *prometheus.GaugeVec
+	applicationLogRateLimitMetric               *prometheus.GaugeVec
+	applicationStackDeprecatedMetric            *prometheus.GaugeVec
 	applicationsScrapesTotalMetric              prometheus.Counter
 	applicationsScrapeErrorsTotalMetric         prometheus.Counter
 	lastApplicationsScrapeErrorMetric           prometheus.Gauge
 	lastApplicationsScrapeTimestampMetric       prometheus.Gauge
 	lastApplicationsScrapeDurationSecondsMetric prometheus.Gauge
+	deprecatedStacks                            []string
 }
 
 func NewApplicationsCollector(
 	namespace string,
 	environment string,
 	deployment string,
+	deprecatedStacks []string,
 ) *ApplicationsCollector {
 	applicationInfoMetric := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -100,6 +104,28 @@ func NewApplicationsCollector(
 		[]string{"application_id", "application_name", "organization_id", "organization_name", "space_id", "space_name"},
 	)
 
+	applicationLogRateLimitMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "application",
+			Name:        "log_rate_limit_bytes_per_second",
+			Help:        "Cloud Foundry Application Process Log Rate Limit (bytes per second).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"application_id", "application_name", "organization_id", "organization_name", "space_id", "space_name", "process_type"},
+	)
+
+	applicationStackDeprecatedMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "application",
+			Name:        "stack_deprecated",
+			Help:        "Whether a Cloud Foundry Application runs on a deprecated stack (1 for deprecated, 0 otherwise).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"application_id", "application_name", "organization_id", "organization_name", "space_id", "space_name", "stack_name"},
+	)
+
 	applicationsScrapesTotalMetric := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace:   namespace,
@@ -160,11 +186,14 @@ func NewApplicationsCollector(
 		applicationInstancesRunningMetric:           applicationInstancesRunningMetric,
 		applicationMemoryMbMetric:                   applicationMemoryMbMetric,
 		applicationDiskQuotaMbMetric:                applicationDiskQuotaMbMetric,
+		applicationLogRateLimitMetric:               applicationLogRateLimitMetric,
+		applicationStackDeprecatedMetric:            applicationStackDeprecatedMetric,
 		applicationsScrapesTotalMetric:              applicationsScrapesTotalMetric,
 		applicationsScrapeErrorsTotalMetric:         applicationsScrapeErrorsTotalMetric,
 		lastApplicationsScrapeErrorMetric:           lastApplicationsScrapeErrorMetric,
 		lastApplicationsScrapeTimestampMetric:       lastApplicationsScrapeTimestampMetric,
 		lastApplicationsScrapeDurationSecondsMetric: lastApplicationsScrapeDurationSecondsMetric,
+		deprecatedStacks:                            deprecatedStacks,
 	}
 }
 
@@ -198,6 +227,8 @@ func (c ApplicationsCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.applicationInstancesRunningMetric.Describe(ch)
 	c.applicationMemoryMbMetric.Describe(ch)
 	c.applicationDiskQuotaMbMetric.Describe(ch)
+	c.applicationLogRateLimitMetric.Describe(ch)
+	c.applicationStackDeprecatedMetric.Describe(ch)
 	c.applicationsScrapesTotalMetric.Describe(ch)
 	c.applicationsScrapeErrorsTotalMetric.Describe(ch)
 	c.applicationBuildpackMetric.Describe(ch)
@@ -242,13 +273,7 @@ func (c ApplicationsCollector) reportApp(application models.Application, objs *m
 		return fmt.Errorf("could not find org with guid '%s'", orgRel.GUID)
 	}
 
-	stackGUID := ""
-	for _, stack := range objs.Stacks {
-		if stack.Name == application.Lifecycle.Data.Stack {
-			stackGUID = stack.GUID
-			break
-		}
-	}
+	stack := objs.StacksByName[application.Lifecycle.Data.Stack]
 	detectedBuildpack, buildpack := c.collectAppBuildpacks(application, objs)
 
 	c.applicationInfoMetric.WithLabelValues(
@@ -260,7 +285,7 @@ func (c ApplicationsCollector) reportApp(application models.Application, objs *m
 		organization.Name,
 		space.GUID,
 		space.Name,
-		stackGUID,
+		stack.GUID,
 		string(application.State),
 	).Set(float64(1))
 
@@ -313,6 +338,31 @@ func (c ApplicationsCollector) reportApp(application models.Application, objs *m
 		space.GUID,
 		space.Name,
 	).Set(float64(process.DiskInMB.Value))
+
+	for _, cProc := range processes {
+		c.applicationLogRateLimitMetric.WithLabelValues(
+			application.GUID,
+			application.Name,
+			organization.GUID,
+			organization.Name,
+			space.GUID,
+			space.Name,
+			cProc.Type,
+		).Set(NullIntToFloat(&cProc.LogRateLimitInBPS))
+	}
+
+	if stack.Name != "" {
+		deprecated := IsDeprecatedStack(stack, c.deprecatedStacks)
+		c.applicationStackDeprecatedMetric.WithLabelValues(
+			application.GUID,
+			application.Name,
+			organization.GUID,
+			organization.Name,
+			space.GUID,
+			space.Name,
+			stack.Name,
+		).Set(BoolToFloat(&deprecated))
+	}
 	return nil
 }
 
@@ -357,6 +407,8 @@ func (c ApplicationsCollector) reportApplicationsMetrics(objs *models.CFObjects,
 	c.applicationInstancesRunningMetric.Reset()
 	c.applicationMemoryMbMetric.Reset()
 	c.applicationDiskQuotaMbMetric.Reset()
+	c.applicationLogRateLimitMetric.Reset()
+	c.applicationStackDeprecatedMetric.Reset()
 	c.applicationBuildpackMetric.Reset()
 
 	for _, application := range objs.Apps {
@@ -373,6 +425,8 @@ func (c ApplicationsCollector) reportApplicationsMetrics(objs *models.CFObjects,
 	c.applicationInstancesRunningMetric.Collect(ch)
 	c.applicationMemoryMbMetric.Collect(ch)
 	c.applicationDiskQuotaMbMetric.Collect(ch)
+	c.applicationLogRateLimitMetric.Collect(ch)
+	c.applicationStackDeprecatedMetric.Collect(ch)
 	c.applicationBuildpackMetric.Collect(ch)
 	return res
 }
@@ -3,6 +3,7 @@ package collectors
 import (
 	"time"
 
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
 	"github.com/cloudfoundry/cf_exporter/v2/models"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -12,6 +13,12 @@ type BuildpacksCollector struct {
 	environment                               string
 	deployment                                string
 	buildpackInfoMetric                       *prometheus.GaugeVec
+	buildpackPositionMetric                   *prometheus.GaugeVec
+	buildpackEnabledMetric                    *prometheus.GaugeVec
+	buildpackLockedMetric                     *prometheus.GaugeVec
+	buildpackCreatedAtMetric                  *prometheus.GaugeVec
+	buildpackUpdatedAtMetric                  *prometheus.GaugeVec
+	buildpackApplicationsMetric               *prometheus.GaugeVec
 	buildpacksScrapesTotalMetric              prometheus.Counter
 	buildpacksScrapeErrorsTotalMetric         prometheus.Counter
 	lastBuildpacksScrapeErrorMetric           prometheus.Gauge
@@ -32,7 +39,73 @@ func NewBuildpacksCollector(
 			Help:        "Labeled Cloud Foundry Buildpack information with a constant '1' value.",
 			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
 		},
-		[]string{"buildpack_id", "buildpack_name", "buildpack_stack", "buildpack_filename"},
+		[]string{"buildpack_id", "buildpack_name", "buildpack_stack", "buildpack_filename", "buildpack_state", "buildpack_lifecycle"},
+	)
+
+	buildpackPositionMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "buildpack",
+			Name:        "position",
+			Help:        "Position of a Cloud Foundry Buildpack in the detection order.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"buildpack_id", "buildpack_name", "buildpack_stack"},
+	)
+
+	buildpackEnabledMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "buildpack",
+			Name:        "enabled",
+			Help:        "Whether a Cloud Foundry Buildpack can be used for staging (1 for enabled, 0 otherwise).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"buildpack_id", "buildpack_name", "buildpack_stack"},
+	)
+
+	buildpackLockedMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "buildpack",
+			Name:        "locked",
+			Help:        "Whether a Cloud Foundry Buildpack is locked against updates (1 for locked, 0 otherwise).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"buildpack_id", "buildpack_name", "buildpack_stack"},
+	)
+
+	buildpackCreatedAtMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "buildpack",
+			Name:        "created_at",
+			Help:        "Number of seconds since 1970 since a Cloud Foundry Buildpack was created.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"buildpack_id", "buildpack_name", "buildpack_stack"},
+	)
+
+	buildpackUpdatedAtMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "buildpack",
+			Name:        "updated_at",
+			Help:        "Number of seconds since 1970 since a Cloud Foundry Buildpack was last updated.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"buildpack_id", "buildpack_name", "buildpack_stack"},
+	)
+
+	buildpackApplicationsMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "buildpack",
+			Name:        "applications",
+			Help:        "Number of Cloud Foundry Applications whose current droplet was staged with a Buildpack.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"buildpack_id", "buildpack_name", "buildpack_stack", "buildpack_lifecycle"},
 	)
 
 	buildpacksScrapesTotalMetric := prometheus.NewCounter(
@@ -90,6 +163,12 @@ func NewBuildpacksCollector(
 		environment:                               environment,
 		deployment:                                deployment,
 		buildpackInfoMetric:                       buildpackInfoMetric,
+		buildpackPositionMetric:                   buildpackPositionMetric,
+		buildpackEnabledMetric:                    buildpackEnabledMetric,
+		buildpackLockedMetric:                     buildpackLockedMetric,
+		buildpackCreatedAtMetric:                  buildpackCreatedAtMetric,
+		buildpackUpdatedAtMetric:                  buildpackUpdatedAtMetric,
+		buildpackApplicationsMetric:               buildpackApplicationsMetric,
 		buildpacksScrapesTotalMetric:              buildpacksScrapesTotalMetric,
 		buildpacksScrapeErrorsTotalMetric:         buildpacksScrapeErrorsTotalMetric,
 		lastBuildpacksScrapeErrorMetric:           lastBuildpacksScrapeErrorMetric,
@@ -120,6 +199,12 @@ func (c BuildpacksCollector) Collect(objs *models.CFObjects, ch chan<- prometheu
 
 func (c BuildpacksCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.buildpackInfoMetric.Describe(ch)
+	c.buildpackPositionMetric.Describe(ch)
+	c.buildpackEnabledMetric.Describe(ch)
+	c.buildpackLockedMetric.Describe(ch)
+	c.buildpackCreatedAtMetric.Describe(ch)
+	c.buildpackUpdatedAtMetric.Describe(ch)
+	c.buildpackApplicationsMetric.Describe(ch)
 	c.buildpacksScrapesTotalMetric.Describe(ch)
 	c.buildpacksScrapeErrorsTotalMetric.Describe(ch)
 	c.lastBuildpacksScrapeErrorMetric.Describe(ch)
@@ -127,8 +212,52 @@ func (c BuildpacksCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.lastBuildpacksScrapeDurationSecondsMetric.Describe(ch)
 }
 
+// findInstalledBuildpack returns the installed buildpack a droplet buildpack
+// was staged with, preferring the one matching the droplet stack and falling
+// back to a buildpack installed without stack
+func findInstalledBuildpack(objs *models.CFObjects, name string, stack string) (models.Buildpack, bool) {
+	res := models.Buildpack{}
+	found := false
+	for _, installed := range objs.Buildpacks {
+		if installed.Name != name {
+			continue
+		}
+		if installed.Stack == stack {
+			return installed, true
+		}
+		if installed.Stack == "" {
+			res = installed
+			found = true
+		}
+	}
+	return res, found
+}
+
+// reportBuildpacksMetrics
+//  1. only consider the droplet an application is currently running
+//  2. report unused buildpacks too, they are the ones safe to remove
 func (c BuildpacksCollector) reportBuildpacksMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
 	c.buildpackInfoMetric.Reset()
+	c.buildpackPositionMetric.Reset()
+	c.buildpackEnabledMetric.Reset()
+	c.buildpackLockedMetric.Reset()
+	c.buildpackCreatedAtMetric.Reset()
+	c.buildpackUpdatedAtMetric.Reset()
+	c.buildpackApplicationsMetric.Reset()
+
+	apps := map[string]int{}
+	for _, app := range objs.Apps {
+		// 1.
+		droplet, ok := objs.Droplets[app.Relationships[constant.RelationshipTypeCurrentDroplet].GUID]
+		if !ok {
+			continue
+		}
+		for _, bp := range droplet.Buildpacks {
+			if installed, ok := findInstalledBuildpack(objs, bp.Name, droplet.Stack); ok {
+				apps[installed.GUID]++
+			}
+		}
+	}
 
 	for _, buildpack := range objs.Buildpacks {
 		c.buildpackInfoMetric.WithLabelValues(
@@ -136,8 +265,60 @@ func (c BuildpacksCollector) reportBuildpacksMetrics(objs *models.CFObjects, ch
 			buildpack.Name,
 			buildpack.Stack,
 			buildpack.Filename,
+			buildpack.State,
+			buildpack.Lifecycle,
 		).Set(float64(1))
+
+		c.buildpackPositionMetric.WithLabelValues(
+			buildpack.GUID,
+			buildpack.Name,
+			buildpack.Stack,
+		).Set(float64(buildpack.Position.Value))
+
+		enabled := buildpack.Enabled.IsSet && buildpack.Enabled.Value
+		c.buildpackEnabledMetric.WithLabelValues(
+			buildpack.GUID,
+			buildpack.Name,
+			buildpack.Stack,
+		).Set(BoolToFloat(&enabled))
+
+		locked := buildpack.Locked.IsSet && buildpack.Locked.Value
+		c.buildpackLockedMetric.WithLabelValues(
+			buildpack.GUID,
+			buildpack.Name,
+			buildpack.Stack,
+		).Set(BoolToFloat(&locked))
+
+		if !buildpack.CreatedAt.IsZero() {
+			c.buildpackCreatedAtMetric.WithLabelValues(
+				buildpack.GUID,
+				buildpack.Name,
+				buildpack.Stack,
+			).Set(float64(buildpack.CreatedAt.Unix()))
+		}
+
+		if !buildpack.UpdatedAt.IsZero() {
+			c.buildpackUpdatedAtMetric.WithLabelValues(
+				buildpack.GUID,
+				buildpack.Name,
+				buildpack.Stack,
+			).Set(float64(buildpack.UpdatedAt.Unix()))
+		}
+
+		// 2.
+		c.buildpackApplicationsMetric.WithLabelValues(
+			buildpack.GUID,
+			buildpack.Name,
+			buildpack.Stack,
+			buildpack.Lifecycle,
+		).Set(float64(apps[buildpack.GUID]))
 	}
 
 	c.buildpackInfoMetric.Collect(ch)
+	c.buildpackPositionMetric.Collect(ch)
+	c.buildpackEnabledMetric.Collect(ch)
+	c.buildpackLockedMetric.Collect(ch)
+	c.buildpackCreatedAtMetric.Collect(ch)
+	c.buildpackUpdatedAtMetric.Collect(ch)
+	c.buildpackApplicationsMetric.Collect(ch)
 }
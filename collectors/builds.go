@@ -0,0 +1,275 @@
+package collectors
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
+	"github.com/cloudfoundry/cf_exporter/v2/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// buildErrorReasons are the staging error reasons reported by the Cloud
+// Controller, any other reason is reported as "other" to bound the label values
+var buildErrorReasons = []string{
+	"BuildpackCompileFailed",
+	"BuildpackReleaseFailed",
+	"InsufficientResources",
+	"NoAppDetectedError",
+	"NoCompatibleCell",
+	"StagingError",
+	"StagingTimeExpired",
+}
+
+type BuildsCollector struct {
+	namespace                             string
+	environment                           string
+	deployment                            string
+	buildsCountMetric                     *prometheus.GaugeVec
+	buildsStagingDurationSecondsSumMetric *prometheus.GaugeVec
+	buildsStagingDurationSecondsMaxMetric *prometheus.GaugeVec
+	buildsScrapesTotalMetric              prometheus.Counter
+	buildsScrapeErrorsTotalMetric         prometheus.Counter
+	lastBuildsScrapeErrorMetric           prometheus.Gauge
+	lastBuildsScrapeTimestampMetric       prometheus.Gauge
+	lastBuildsScrapeDurationSecondsMetric prometheus.Gauge
+}
+
+func NewBuildsCollector(
+	namespace string,
+	environment string,
+	deployment string,
+) *BuildsCollector {
+	buildsCountMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "builds",
+			Name:        "count",
+			Help:        "Number of Cloud Foundry Builds.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"organization_id", "organization_name", "space_id", "space_name", "state", "lifecycle_type", "error"},
+	)
+
+	buildsStagingDurationSecondsSumMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "builds",
+			Name:        "staging_duration_seconds_sum",
+			Help:        "Sum of the staging duration of finished Cloud Foundry Builds.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"organization_id", "organization_name", "space_id", "space_name", "state", "lifecycle_type", "error"},
+	)
+
+	buildsStagingDurationSecondsMaxMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "builds",
+			Name:        "staging_duration_seconds_max",
+			Help:        "Maximum staging duration of finished Cloud Foundry Builds.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"organization_id", "organization_name", "space_id", "space_name", "state", "lifecycle_type", "error"},
+	)
+
+	buildsScrapesTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "builds_scrapes",
+			Name:        "total",
+			Help:        "Total number of scrapes for Cloud Foundry Builds.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	buildsScrapeErrorsTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "builds_scrape_errors",
+			Name:        "total",
+			Help:        "Total number of scrape error of Cloud Foundry Builds.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastBuildsScrapeErrorMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_builds_scrape_error",
+			Help:        "Whether the last scrape of Builds metrics from Cloud Foundry resulted in an error (1 for error, 0 for success).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastBuildsScrapeTimestampMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_builds_scrape_timestamp",
+			Help:        "Number of seconds since 1970 since last scrape of Builds metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastBuildsScrapeDurationSecondsMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_builds_scrape_duration_seconds",
+			Help:        "Duration of the last scrape of Builds metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	return &BuildsCollector{
+		namespace:                             namespace,
+		environment:                           environment,
+		deployment:                            deployment,
+		buildsCountMetric:                     buildsCountMetric,
+		buildsStagingDurationSecondsSumMetric: buildsStagingDurationSecondsSumMetric,
+		buildsStagingDurationSecondsMaxMetric: buildsStagingDurationSecondsMaxMetric,
+		buildsScrapesTotalMetric:              buildsScrapesTotalMetric,
+		buildsScrapeErrorsTotalMetric:         buildsScrapeErrorsTotalMetric,
+		lastBuildsScrapeErrorMetric:           lastBuildsScrapeErrorMetric,
+		lastBuildsScrapeTimestampMetric:       lastBuildsScrapeTimestampMetric,
+		lastBuildsScrapeDurationSecondsMetric: lastBuildsScrapeDurationSecondsMetric,
+	}
+}
+
+func (c BuildsCollector) Collect(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	errorMetric := float64(0)
+	if objs.Error != nil {
+		errorMetric = float64(1)
+		c.buildsScrapeErrorsTotalMetric.Inc()
+	} else {
+		c.reportBuildsMetrics(objs, ch)
+	}
+
+	c.buildsScrapeErrorsTotalMetric.Collect(ch)
+	c.buildsScrapesTotalMetric.Inc()
+	c.buildsScrapesTotalMetric.Collect(ch)
+	c.lastBuildsScrapeErrorMetric.Set(errorMetric)
+	c.lastBuildsScrapeErrorMetric.Collect(ch)
+	c.lastBuildsScrapeTimestampMetric.Set(float64(time.Now().Unix()))
+	c.lastBuildsScrapeTimestampMetric.Collect(ch)
+	c.lastBuildsScrapeDurationSecondsMetric.Set(objs.Took)
+	c.lastBuildsScrapeDurationSecondsMetric.Collect(ch)
+}
+
+func (c BuildsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.buildsCountMetric.Describe(ch)
+	c.buildsStagingDurationSecondsSumMetric.Describe(ch)
+	c.buildsStagingDurationSecondsMaxMetric.Describe(ch)
+	c.buildsScrapesTotalMetric.Describe(ch)
+	c.buildsScrapeErrorsTotalMetric.Describe(ch)
+	c.lastBuildsScrapeErrorMetric.Describe(ch)
+	c.lastBuildsScrapeTimestampMetric.Describe(ch)
+	c.lastBuildsScrapeDurationSecondsMetric.Describe(ch)
+}
+
+// reportBuildsMetrics
+//  1. resolve space and organization through the build application
+//  2. only keep the error reason, ie: "StagingError" for
+//     "StagingError - Staging error: staging failed", unknown reasons being
+//     reported as "other"
+//  3. staging duration is only known once the build is no longer staging
+func (c BuildsCollector) reportBuildsMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	c.buildsCountMetric.Reset()
+	c.buildsStagingDurationSecondsSumMetric.Reset()
+	c.buildsStagingDurationSecondsMaxMetric.Reset()
+
+	type keyType struct {
+		organizationID   string
+		organizationName string
+		spaceID          string
+		spaceName        string
+		state            string
+		lifecycleType    string
+		errorReason      string
+	}
+	counts := map[keyType]int{}
+	durationsSum := map[keyType]float64{}
+	durationsMax := map[keyType]float64{}
+
+	for _, build := range objs.Builds {
+		// 1.
+		key := keyType{state: string(build.State), lifecycleType: string(build.Lifecycle.Type)}
+		if app, ok := objs.Apps[build.Relationships[constant.RelationshipTypeApplication].GUID]; ok {
+			key.spaceID = app.Relationships[constant.RelationshipTypeSpace].GUID
+			if space, ok := objs.Spaces[key.spaceID]; ok {
+				key.spaceName = space.Name
+				key.organizationID = space.Relationships[constant.RelationshipTypeOrganization].GUID
+			}
+			if org, ok := objs.Orgs[key.organizationID]; ok {
+				key.organizationName = org.Name
+			}
+		}
+
+		// 2.
+		key.errorReason = buildErrorReason(build.Error)
+
+		counts[key]++
+
+		// 3.
+		if build.State == constant.BuildStaging {
+			continue
+		}
+		duration := build.UpdatedAt.Sub(build.CreatedAt).Seconds()
+		durationsSum[key] += duration
+		if duration > durationsMax[key] {
+			durationsMax[key] = duration
+		}
+	}
+
+	for key, count := range counts {
+		c.buildsCountMetric.WithLabelValues(
+			key.organizationID,
+			key.organizationName,
+			key.spaceID,
+			key.spaceName,
+			key.state,
+			key.lifecycleType,
+			key.errorReason,
+		).Set(float64(count))
+	}
+
+	for key, sum := range durationsSum {
+		c.buildsStagingDurationSecondsSumMetric.WithLabelValues(
+			key.organizationID,
+			key.organizationName,
+			key.spaceID,
+			key.spaceName,
+			key.state,
+			key.lifecycleType,
+			key.errorReason,
+		).Set(sum)
+
+		c.buildsStagingDurationSecondsMaxMetric.WithLabelValues(
+			key.organizationID,
+			key.organizationName,
+			key.spaceID,
+			key.spaceName,
+			key.state,
+			key.lifecycleType,
+			key.errorReason,
+		).Set(durationsMax[key])
+	}
+
+	c.buildsCountMetric.Collect(ch)
+	c.buildsStagingDurationSecondsSumMetric.Collect(ch)
+	c.buildsStagingDurationSecondsMaxMetric.Collect(ch)
+}
+
+func buildErrorReason(buildError string) string {
+	if buildError == "" {
+		return ""
+	}
+	reason := strings.TrimSpace(strings.SplitN(buildError, " - ", 2)[0])
+	if slices.Contains(buildErrorReasons, reason) {
+		return reason
+	}
+	return "other"
+}
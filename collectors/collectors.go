@@ -1,6 +1,8 @@
 package collectors
 
 import (
+	"time"
+
 	"github.com/cloudfoundry/cf_exporter/v2/fetcher"
 	"github.com/cloudfoundry/cf_exporter/v2/filters"
 	"github.com/cloudfoundry/cf_exporter/v2/models"
@@ -12,12 +14,24 @@ type ObjectCollector interface {
 	Describe(ch chan<- *prometheus.Desc)
 }
 
+// Config holds the collectors settings which are not related to the objects
+// fetched from Cloud Foundry
+type Config struct {
+	ServiceInstanceStuckThreshold time.Duration
+	ServiceKeyMaxAge              time.Duration
+	DeprecatedStacks              []string
+	EventsStore                   EventsStore
+	UsageEventsStore              UsageEventsStore
+}
+
 type Collector struct {
 	workers    int
 	cfConfig   *fetcher.CFConfig
 	bbsConfig  *fetcher.BBSConfig
 	filter     *filters.Filter
 	collectors []ObjectCollector
+	events     *EventsCollector
+	usage      *UsageEventsCollector
 }
 
 func NewCollector(
@@ -28,6 +42,7 @@ func NewCollector(
 	cfConfig *fetcher.CFConfig,
 	bbsConfig *fetcher.BBSConfig,
 	filter *filters.Filter,
+	config *Config,
 ) (*Collector, error) {
 	res := &Collector{
 		workers:    workers,
@@ -38,7 +53,7 @@ func NewCollector(
 	}
 
 	if filter.Enabled(filters.Applications) {
-		collector := NewApplicationsCollector(namespace, environment, deployment)
+		collector := NewApplicationsCollector(namespace, environment, deployment, config.DeprecatedStacks)
 		res.collectors = append(res.collectors, collector)
 	}
 
@@ -52,6 +67,26 @@ func NewCollector(
 		res.collectors = append(res.collectors, collector)
 	}
 
+	if filter.Enabled(filters.FeatureFlags) {
+		collector := NewFeatureFlagsCollector(namespace, environment, deployment)
+		res.collectors = append(res.collectors, collector)
+	}
+
+	if filter.Enabled(filters.Droplets) {
+		collector := NewDropletsCollector(namespace, environment, deployment)
+		res.collectors = append(res.collectors, collector)
+	}
+
+	if filter.Enabled(filters.Builds) {
+		collector := NewBuildsCollector(namespace, environment, deployment)
+		res.collectors = append(res.collectors, collector)
+	}
+
+	if filter.Enabled(filters.Deployments) {
+		collector := NewDeploymentsCollector(namespace, environment, deployment)
+		res.collectors = append(res.collectors, collector)
+	}
+
 	if filter.Enabled(filters.IsolationSegments) {
 		collector := NewIsolationSegmentsCollector(namespace, environment, deployment)
 		res.collectors = append(res.collectors, collector)
@@ -62,6 +97,11 @@ func NewCollector(
 		res.collectors = append(res.collectors, collector)
 	}
 
+	if filter.Enabled(filters.Roles) {
+		collector := NewRolesCollector(namespace, environment, deployment)
+		res.collectors = append(res.collectors, collector)
+	}
+
 	if filter.Enabled(filters.Routes) {
 		collector := NewRoutesCollector(namespace, environment, deployment)
 		res.collectors = append(res.collectors, collector)
@@ -72,6 +112,11 @@ func NewCollector(
 		res.collectors = append(res.collectors, collector)
 	}
 
+	if filter.Enabled(filters.SecurityPosture) {
+		collector := NewSecurityPostureCollector(namespace, environment, deployment, config.ServiceKeyMaxAge, config.DeprecatedStacks)
+		res.collectors = append(res.collectors, collector)
+	}
+
 	if filter.Enabled(filters.ServiceBindings) {
 		collector := NewServiceBindingsCollector(namespace, environment, deployment)
 		res.collectors = append(res.collectors, collector)
@@ -82,8 +127,13 @@ func NewCollector(
 		res.collectors = append(res.collectors, collector)
 	}
 
+	if filter.Enabled(filters.ServiceBrokers) {
+		collector := NewServiceBrokersCollector(namespace, environment, deployment)
+		res.collectors = append(res.collectors, collector)
+	}
+
 	if filter.Enabled(filters.ServiceInstances) {
-		collector := NewServiceInstancesCollector(namespace, environment, deployment)
+		collector := NewServiceInstancesCollector(namespace, environment, deployment, config.ServiceInstanceStuckThreshold)
 		res.collectors = append(res.collectors, collector)
 	}
 
@@ -98,7 +148,7 @@ func NewCollector(
 	}
 
 	if filter.Enabled(filters.Stacks) {
-		collector := NewStacksCollector(namespace, environment, deployment)
+		collector := NewStacksCollector(namespace, environment, deployment, config.DeprecatedStacks)
 		res.collectors = append(res.collectors, collector)
 	}
 
@@ -113,15 +163,36 @@ func NewCollector(
 	}
 
 	if filter.Enabled(filters.Events) {
-		collector := NewEventsCollector(namespace, environment, deployment)
+		collector := NewEventsCollector(namespace, environment, deployment, config.EventsStore)
+		res.collectors = append(res.collectors, collector)
+		res.events = collector
+	}
+
+	if filter.Enabled(filters.UsageEvents) {
+		collector := NewUsageEventsCollector(namespace, environment, deployment, config.UsageEventsStore)
 		res.collectors = append(res.collectors, collector)
+		res.usage = collector
 	}
 
 	return res, nil
 }
 
+// Collect
+// 1. events are fetched since the last successful events scrape, which may be
+// older than the configured lookback after a restart with a persisted state
+// 2. usage events are fetched after the last ones consumed
 func (c *Collector) Collect(ch chan<- prometheus.Metric) {
-	fetcher := fetcher.NewFetcher(c.workers, c.cfConfig, c.bbsConfig, c.filter)
+	cfConfig := *c.cfConfig
+	// 1.
+	if c.events != nil {
+		cfConfig.EventsSince = c.events.LastCheck()
+	}
+	// 2.
+	if c.usage != nil {
+		cfConfig.AppUsageAfter, cfConfig.ServiceUsageAfter = c.usage.Cursors()
+	}
+
+	fetcher := fetcher.NewFetcher(c.workers, &cfConfig, c.bbsConfig, c.filter)
 	objs := fetcher.GetObjects()
 
 	for _, collector := range c.collectors {
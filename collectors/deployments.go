@@ -0,0 +1,287 @@
+package collectors
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
+	"github.com/cloudfoundry/cf_exporter/v2/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type DeploymentsCollector struct {
+	namespace                                  string
+	environment                                string
+	deployment                                 string
+	deploymentInfoMetric                       *prometheus.GaugeVec
+	deploymentCreatedAtMetric                  *prometheus.GaugeVec
+	deploymentLastStatusChangeMetric           *prometheus.GaugeVec
+	deploymentProgressMetric                   *prometheus.GaugeVec
+	deploymentsFinishedTotalMetric             *prometheus.CounterVec
+	deploymentsScrapesTotalMetric              prometheus.Counter
+	deploymentsScrapeErrorsTotalMetric         prometheus.Counter
+	lastDeploymentsScrapeErrorMetric           prometheus.Gauge
+	lastDeploymentsScrapeTimestampMetric       prometheus.Gauge
+	lastDeploymentsScrapeDurationSecondsMetric prometheus.Gauge
+	countedDeployments                         map[string]struct{}
+	startedAt                                  time.Time
+}
+
+func NewDeploymentsCollector(
+	namespace string,
+	environment string,
+	deployment string,
+) *DeploymentsCollector {
+	deploymentInfoMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "deployment",
+			Name:        "info",
+			Help:        "Labeled Cloud Foundry active Deployment information with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"deployment_id", "application_id", "application_name", "strategy", "status_reason"},
+	)
+
+	deploymentCreatedAtMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "deployment",
+			Name:        "created_at",
+			Help:        "Number of seconds since 1970 since a Cloud Foundry active Deployment was started.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"deployment_id", "application_id", "application_name"},
+	)
+
+	deploymentLastStatusChangeMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "deployment",
+			Name:        "last_status_change",
+			Help:        "Number of seconds since 1970 since a Cloud Foundry active Deployment last changed status.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"deployment_id", "application_id", "application_name"},
+	)
+
+	deploymentProgressMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "deployment",
+			Name:        "progress",
+			Help:        "Ratio of completed steps of a Cloud Foundry active canary Deployment.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"deployment_id", "application_id", "application_name"},
+	)
+
+	deploymentsFinishedTotalMetric := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "deployments",
+			Name:        "finished_total",
+			Help:        "Total number of finalized Cloud Foundry Deployments by status reason.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"strategy", "status_reason"},
+	)
+
+	deploymentsScrapesTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "deployments_scrapes",
+			Name:        "total",
+			Help:        "Total number of scrapes for Cloud Foundry Deployments.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	deploymentsScrapeErrorsTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "deployments_scrape_errors",
+			Name:        "total",
+			Help:        "Total number of scrape error of Cloud Foundry Deployments.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastDeploymentsScrapeErrorMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_deployments_scrape_error",
+			Help:        "Whether the last scrape of Deployments metrics from Cloud Foundry resulted in an error (1 for error, 0 for success).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastDeploymentsScrapeTimestampMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_deployments_scrape_timestamp",
+			Help:        "Number of seconds since 1970 since last scrape of Deployments metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastDeploymentsScrapeDurationSecondsMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_deployments_scrape_duration_seconds",
+			Help:        "Duration of the last scrape of Deployments metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	return &DeploymentsCollector{
+		namespace:                                  namespace,
+		environment:                                environment,
+		deployment:                                 deployment,
+		deploymentInfoMetric:                       deploymentInfoMetric,
+		deploymentCreatedAtMetric:                  deploymentCreatedAtMetric,
+		deploymentLastStatusChangeMetric:           deploymentLastStatusChangeMetric,
+		deploymentProgressMetric:                   deploymentProgressMetric,
+		deploymentsFinishedTotalMetric:             deploymentsFinishedTotalMetric,
+		deploymentsScrapesTotalMetric:              deploymentsScrapesTotalMetric,
+		deploymentsScrapeErrorsTotalMetric:         deploymentsScrapeErrorsTotalMetric,
+		lastDeploymentsScrapeErrorMetric:           lastDeploymentsScrapeErrorMetric,
+		lastDeploymentsScrapeTimestampMetric:       lastDeploymentsScrapeTimestampMetric,
+		lastDeploymentsScrapeDurationSecondsMetric: lastDeploymentsScrapeDurationSecondsMetric,
+		countedDeployments:                         map[string]struct{}{},
+		startedAt:                                  time.Now(),
+	}
+}
+
+func (c *DeploymentsCollector) Collect(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	errorMetric := float64(0)
+	if objs.Error != nil {
+		errorMetric = float64(1)
+		c.deploymentsScrapeErrorsTotalMetric.Inc()
+	} else {
+		c.reportDeploymentsMetrics(objs, ch)
+		c.reportFinishedDeploymentsMetrics(objs)
+	}
+
+	c.deploymentsFinishedTotalMetric.Collect(ch)
+	c.deploymentsScrapeErrorsTotalMetric.Collect(ch)
+	c.deploymentsScrapesTotalMetric.Inc()
+	c.deploymentsScrapesTotalMetric.Collect(ch)
+	c.lastDeploymentsScrapeErrorMetric.Set(errorMetric)
+	c.lastDeploymentsScrapeErrorMetric.Collect(ch)
+	c.lastDeploymentsScrapeTimestampMetric.Set(float64(time.Now().Unix()))
+	c.lastDeploymentsScrapeTimestampMetric.Collect(ch)
+	c.lastDeploymentsScrapeDurationSecondsMetric.Set(objs.Took)
+	c.lastDeploymentsScrapeDurationSecondsMetric.Collect(ch)
+}
+
+func (c *DeploymentsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.deploymentInfoMetric.Describe(ch)
+	c.deploymentCreatedAtMetric.Describe(ch)
+	c.deploymentLastStatusChangeMetric.Describe(ch)
+	c.deploymentProgressMetric.Describe(ch)
+	c.deploymentsFinishedTotalMetric.Describe(ch)
+	c.deploymentsScrapesTotalMetric.Describe(ch)
+	c.deploymentsScrapeErrorsTotalMetric.Describe(ch)
+	c.lastDeploymentsScrapeErrorMetric.Describe(ch)
+	c.lastDeploymentsScrapeTimestampMetric.Describe(ch)
+	c.lastDeploymentsScrapeDurationSecondsMetric.Describe(ch)
+}
+
+// reportDeploymentsMetrics
+//  1. only report deployments that are still in progress
+//  2. progress is only known for canary deployments, from the current step
+//     among the configured ones
+func (c *DeploymentsCollector) reportDeploymentsMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	c.deploymentInfoMetric.Reset()
+	c.deploymentCreatedAtMetric.Reset()
+	c.deploymentLastStatusChangeMetric.Reset()
+	c.deploymentProgressMetric.Reset()
+
+	for _, deployment := range objs.Deployments {
+		// 1.
+		if deployment.StatusValue != constant.DeploymentStatusValueActive {
+			continue
+		}
+
+		applicationID := deployment.Relationships[constant.RelationshipTypeApplication].GUID
+		applicationName := ""
+		if app, ok := objs.Apps[applicationID]; ok {
+			applicationName = app.Name
+		}
+
+		c.deploymentInfoMetric.WithLabelValues(
+			deployment.GUID,
+			applicationID,
+			applicationName,
+			string(deployment.Strategy),
+			string(deployment.StatusReason),
+		).Set(float64(1))
+
+		if createdAt, err := time.Parse(time.RFC3339, deployment.CreatedAt); err == nil {
+			c.deploymentCreatedAtMetric.WithLabelValues(
+				deployment.GUID,
+				applicationID,
+				applicationName,
+			).Set(float64(createdAt.Unix()))
+		}
+
+		if lastStatusChange, err := time.Parse(time.RFC3339, deployment.LastStatusChange); err == nil {
+			c.deploymentLastStatusChangeMetric.WithLabelValues(
+				deployment.GUID,
+				applicationID,
+				applicationName,
+			).Set(float64(lastStatusChange.Unix()))
+		}
+
+		// 2.
+		steps := deployment.CanaryStatus.Steps
+		if deployment.Strategy == constant.DeploymentStrategyCanary && steps.TotalSteps > 0 {
+			c.deploymentProgressMetric.WithLabelValues(
+				deployment.GUID,
+				applicationID,
+				applicationName,
+			).Set(float64(steps.CurrentStep) / float64(steps.TotalSteps))
+		}
+	}
+
+	c.deploymentInfoMetric.Collect(ch)
+	c.deploymentCreatedAtMetric.Collect(ch)
+	c.deploymentLastStatusChangeMetric.Collect(ch)
+	c.deploymentProgressMetric.Collect(ch)
+}
+
+// reportFinishedDeploymentsMetrics
+//  1. iterate finalized deployments, incrementing the counter once per unique deployment
+//  2. deployments finalized before the collector started are part of the history
+//     and never counted, otherwise each restart would add them again
+func (c *DeploymentsCollector) reportFinishedDeploymentsMetrics(objs *models.CFObjects) {
+	stillPresent := make(map[string]struct{})
+
+	for guid, deployment := range objs.Deployments {
+		// 1.
+		if deployment.StatusValue != constant.DeploymentStatusValueFinalized {
+			continue
+		}
+
+		stillPresent[guid] = struct{}{}
+		if _, counted := c.countedDeployments[guid]; counted {
+			continue
+		}
+
+		// 2.
+		lastStatusChange, err := time.Parse(time.RFC3339, deployment.LastStatusChange)
+		if err != nil || lastStatusChange.Before(c.startedAt) {
+			continue
+		}
+
+		c.deploymentsFinishedTotalMetric.WithLabelValues(
+			string(deployment.Strategy),
+			string(deployment.StatusReason),
+		).Inc()
+	}
+
+	c.countedDeployments = stillPresent
+}
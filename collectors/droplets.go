@@ -0,0 +1,339 @@
+package collectors
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
+	"github.com/cloudfoundry/cf_exporter/v2/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// buildpackFilenameVersionRegexp matches the last version of a buildpack
+// filename such as ruby_buildpack-cached-cflinuxfs4-v1.10.11.zip, followed only
+// by an optional pre-release or build suffix and the archive extension
+var buildpackFilenameVersionRegexp = regexp.MustCompile(`^(?:.*[-_])?v?(\d+(?:\.\d+)+)(?:[-+][0-9A-Za-z.-]*?)?(?:\.zip|\.tgz|\.tar\.gz)?$`)
+
+type DropletsCollector struct {
+	namespace                               string
+	environment                             string
+	deployment                              string
+	dropletInfoMetric                       *prometheus.GaugeVec
+	dropletBuildpackMetric                  *prometheus.GaugeVec
+	dropletCreatedAtMetric                  *prometheus.GaugeVec
+	dropletUpdatedAtMetric                  *prometheus.GaugeVec
+	buildpackVersionApplicationsMetric      *prometheus.GaugeVec
+	applicationBuildpackOutdatedMetric      *prometheus.GaugeVec
+	dropletsScrapesTotalMetric              prometheus.Counter
+	dropletsScrapeErrorsTotalMetric         prometheus.Counter
+	lastDropletsScrapeErrorMetric           prometheus.Gauge
+	lastDropletsScrapeTimestampMetric       prometheus.Gauge
+	lastDropletsScrapeDurationSecondsMetric prometheus.Gauge
+}
+
+func NewDropletsCollector(
+	namespace string,
+	environment string,
+	deployment string,
+) *DropletsCollector {
+	dropletInfoMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "droplet",
+			Name:        "info",
+			Help:        "Labeled Cloud Foundry Droplet information with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"droplet_id", "application_id", "application_name", "state", "lifecycle_type", "stack", "image", "current"},
+	)
+
+	dropletBuildpackMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "droplet",
+			Name:        "buildpack",
+			Help:        "Buildpack used to stage a Cloud Foundry Droplet.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"droplet_id", "application_id", "application_name", "buildpack", "buildpack_name", "buildpack_version", "detect_output", "current"},
+	)
+
+	dropletCreatedAtMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "droplet",
+			Name:        "created_at",
+			Help:        "Number of seconds since 1970 since a Cloud Foundry Droplet was created.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"droplet_id", "application_id", "application_name", "current"},
+	)
+
+	dropletUpdatedAtMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "droplet",
+			Name:        "updated_at",
+			Help:        "Number of seconds since 1970 since a Cloud Foundry Droplet was last updated.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"droplet_id", "application_id", "application_name", "current"},
+	)
+
+	buildpackVersionApplicationsMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "buildpack",
+			Name:        "version_applications",
+			Help:        "Number of Cloud Foundry Applications whose current droplet was staged with a Buildpack version.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"buildpack", "buildpack_name", "buildpack_version", "stack"},
+	)
+
+	applicationBuildpackOutdatedMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "application",
+			Name:        "buildpack_outdated",
+			Help:        "Whether a Cloud Foundry Application current droplet was staged with an older version than the installed Buildpack (1 for outdated, 0 for up to date).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"application_id", "application_name", "buildpack", "buildpack_name", "buildpack_version", "installed_version", "stack"},
+	)
+
+	dropletsScrapesTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "droplets_scrapes",
+			Name:        "total",
+			Help:        "Total number of scrapes for Cloud Foundry Droplets.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	dropletsScrapeErrorsTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "droplets_scrape_errors",
+			Name:        "total",
+			Help:        "Total number of scrape error of Cloud Foundry Droplets.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastDropletsScrapeErrorMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_droplets_scrape_error",
+			Help:        "Whether the last scrape of Droplets metrics from Cloud Foundry resulted in an error (1 for error, 0 for success).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastDropletsScrapeTimestampMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_droplets_scrape_timestamp",
+			Help:        "Number of seconds since 1970 since last scrape of Droplets metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastDropletsScrapeDurationSecondsMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_droplets_scrape_duration_seconds",
+			Help:        "Duration of the last scrape of Droplets metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	return &DropletsCollector{
+		namespace:                               namespace,
+		environment:                             environment,
+		deployment:                              deployment,
+		dropletInfoMetric:                       dropletInfoMetric,
+		dropletBuildpackMetric:                  dropletBuildpackMetric,
+		dropletCreatedAtMetric:                  dropletCreatedAtMetric,
+		dropletUpdatedAtMetric:                  dropletUpdatedAtMetric,
+		buildpackVersionApplicationsMetric:      buildpackVersionApplicationsMetric,
+		applicationBuildpackOutdatedMetric:      applicationBuildpackOutdatedMetric,
+		dropletsScrapesTotalMetric:              dropletsScrapesTotalMetric,
+		dropletsScrapeErrorsTotalMetric:         dropletsScrapeErrorsTotalMetric,
+		lastDropletsScrapeErrorMetric:           lastDropletsScrapeErrorMetric,
+		lastDropletsScrapeTimestampMetric:       lastDropletsScrapeTimestampMetric,
+		lastDropletsScrapeDurationSecondsMetric: lastDropletsScrapeDurationSecondsMetric,
+	}
+}
+
+func (c DropletsCollector) Collect(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	errorMetric := float64(0)
+	if objs.Error != nil {
+		errorMetric = float64(1)
+		c.dropletsScrapeErrorsTotalMetric.Inc()
+	} else {
+		c.reportDropletsMetrics(objs, ch)
+		c.reportBuildpackVersionsMetrics(objs, ch)
+	}
+
+	c.dropletsScrapeErrorsTotalMetric.Collect(ch)
+	c.dropletsScrapesTotalMetric.Inc()
+	c.dropletsScrapesTotalMetric.Collect(ch)
+	c.lastDropletsScrapeErrorMetric.Set(errorMetric)
+	c.lastDropletsScrapeErrorMetric.Collect(ch)
+	c.lastDropletsScrapeTimestampMetric.Set(float64(time.Now().Unix()))
+	c.lastDropletsScrapeTimestampMetric.Collect(ch)
+	c.lastDropletsScrapeDurationSecondsMetric.Set(objs.Took)
+	c.lastDropletsScrapeDurationSecondsMetric.Collect(ch)
+}
+
+func (c DropletsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.dropletInfoMetric.Describe(ch)
+	c.dropletBuildpackMetric.Describe(ch)
+	c.dropletCreatedAtMetric.Describe(ch)
+	c.dropletUpdatedAtMetric.Describe(ch)
+	c.buildpackVersionApplicationsMetric.Describe(ch)
+	c.applicationBuildpackOutdatedMetric.Describe(ch)
+	c.dropletsScrapesTotalMetric.Describe(ch)
+	c.dropletsScrapeErrorsTotalMetric.Describe(ch)
+	c.lastDropletsScrapeErrorMetric.Describe(ch)
+	c.lastDropletsScrapeTimestampMetric.Describe(ch)
+	c.lastDropletsScrapeDurationSecondsMetric.Describe(ch)
+}
+
+// reportDropletsMetrics
+//  1. a droplet is current when it is referenced by the current_droplet
+//     relationship of its application
+//  2. resolve application name from the fetched objects when available
+func (c DropletsCollector) reportDropletsMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	c.dropletInfoMetric.Reset()
+	c.dropletBuildpackMetric.Reset()
+	c.dropletCreatedAtMetric.Reset()
+	c.dropletUpdatedAtMetric.Reset()
+
+	for _, droplet := range objs.Droplets {
+		applicationID := droplet.Relationships[constant.RelationshipTypeApplication].GUID
+		applicationName := ""
+		current := false
+		if app, ok := objs.Apps[applicationID]; ok {
+			// 1.
+			current = app.Relationships[constant.RelationshipTypeCurrentDroplet].GUID == droplet.GUID
+			// 2.
+			applicationName = app.Name
+		}
+
+		c.dropletInfoMetric.WithLabelValues(
+			droplet.GUID,
+			applicationID,
+			applicationName,
+			string(droplet.State),
+			droplet.Lifecycle.Type,
+			droplet.Stack,
+			droplet.Image,
+			strconv.FormatBool(current),
+		).Set(float64(1))
+
+		for _, bp := range droplet.Buildpacks {
+			c.dropletBuildpackMetric.WithLabelValues(
+				droplet.GUID,
+				applicationID,
+				applicationName,
+				bp.Name,
+				bp.BuildpackName,
+				bp.Version,
+				bp.DetectOutput,
+				strconv.FormatBool(current),
+			).Set(float64(1))
+		}
+
+		c.dropletCreatedAtMetric.WithLabelValues(
+			droplet.GUID,
+			applicationID,
+			applicationName,
+			strconv.FormatBool(current),
+		).Set(float64(droplet.CreatedAt.Unix()))
+
+		c.dropletUpdatedAtMetric.WithLabelValues(
+			droplet.GUID,
+			applicationID,
+			applicationName,
+			strconv.FormatBool(current),
+		).Set(float64(droplet.UpdatedAt.Unix()))
+	}
+
+	c.dropletInfoMetric.Collect(ch)
+	c.dropletBuildpackMetric.Collect(ch)
+	c.dropletCreatedAtMetric.Collect(ch)
+	c.dropletUpdatedAtMetric.Collect(ch)
+}
+
+// reportBuildpackVersionsMetrics
+//  1. only consider the droplet an application is currently running
+//  2. installed buildpacks do not expose a version, extract it from the
+//     uploaded filename (ie: go_buildpack-cflinuxfs4-v1.10.0.zip)
+func (c DropletsCollector) reportBuildpackVersionsMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	c.buildpackVersionApplicationsMetric.Reset()
+	c.applicationBuildpackOutdatedMetric.Reset()
+
+	type keyType struct {
+		buildpack string
+		name      string
+		version   string
+		stack     string
+	}
+	counts := map[keyType]int{}
+
+	for _, app := range objs.Apps {
+		// 1.
+		droplet, ok := objs.Droplets[app.Relationships[constant.RelationshipTypeCurrentDroplet].GUID]
+		if !ok {
+			continue
+		}
+
+		for _, bp := range droplet.Buildpacks {
+			counts[keyType{bp.Name, bp.BuildpackName, bp.Version, droplet.Stack}]++
+
+			// 2.
+			installedVersion := ""
+			if installed, ok := findInstalledBuildpack(objs, bp.Name, droplet.Stack); ok {
+				if match := buildpackFilenameVersionRegexp.FindStringSubmatch(installed.Filename); match != nil {
+					installedVersion = match[1]
+				}
+			}
+			if installedVersion == "" || bp.Version == "" {
+				continue
+			}
+
+			outdated := float64(0)
+			if CompareVersions(bp.Version, installedVersion) < 0 {
+				outdated = float64(1)
+			}
+			c.applicationBuildpackOutdatedMetric.WithLabelValues(
+				app.GUID,
+				app.Name,
+				bp.Name,
+				bp.BuildpackName,
+				bp.Version,
+				installedVersion,
+				droplet.Stack,
+			).Set(outdated)
+		}
+	}
+
+	for key, count := range counts {
+		c.buildpackVersionApplicationsMetric.WithLabelValues(
+			key.buildpack,
+			key.name,
+			key.version,
+			key.stack,
+		).Set(float64(count))
+	}
+
+	c.buildpackVersionApplicationsMetric.Collect(ch)
+	c.applicationBuildpackOutdatedMetric.Collect(ch)
+}
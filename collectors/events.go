@@ -2,10 +2,12 @@ package collectors
 
 import (
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cloudfoundry/cf_exporter/v2/models"
 	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
 )
 
 type EventsCollector struct {
@@ -14,6 +16,9 @@ type EventsCollector struct {
 	deployment                            string
 	eventsInfoMetric                      *prometheus.GaugeVec
 	applicationCrashesTotalMetric         *prometheus.CounterVec
+	applicationCrashReasonsTotalMetric    *prometheus.CounterVec
+	applicationActivityTotalMetric        *prometheus.CounterVec
+	eventsTotalMetric                     *prometheus.CounterVec
 	eventsScrapesTotalMetric              prometheus.Counter
 	eventsScrapeErrorsTotalMetric         prometheus.Counter
 	lastEventsScrapeErrorMetric           prometheus.Gauge
@@ -22,12 +27,15 @@ type EventsCollector struct {
 	lastCheckFilter                       time.Time
 	timeLocation                          *time.Location
 	countedCrashEvents                    map[string]struct{}
+	countedEvents                         map[string]struct{}
+	store                                 EventsStore
 }
 
 func NewEventsCollector(
 	namespace string,
 	environment string,
 	deployment string,
+	store EventsStore,
 ) *EventsCollector {
 	eventsInfoMetric := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -51,6 +59,39 @@ func NewEventsCollector(
 		[]string{"application_id", "application_name", "organization_id", "organization_name", "space_id", "space_name", "instance"},
 	)
 
+	applicationCrashReasonsTotalMetric := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "application",
+			Name:        "crash_reasons_total",
+			Help:        "Total number of Cloud Foundry Application instance crashes by reason, exit status and cause.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"application_id", "application_name", "organization_id", "organization_name", "space_id", "space_name", "reason", "exit_status", "cause"},
+	)
+
+	applicationActivityTotalMetric := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "application",
+			Name:        "activity_total",
+			Help:        "Total number of Cloud Foundry Application deployments, stagings, restages, restarts and scaling operations.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"application_id", "application_name", "organization_id", "organization_name", "space_id", "space_name", "activity"},
+	)
+
+	eventsTotalMetric := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "events",
+			Name:        "total",
+			Help:        "Total number of Cloud Foundry Events.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"type", "organization_id", "space_id", "actor_type"},
+	)
+
 	eventsScrapesTotalMetric := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace:   namespace,
@@ -104,12 +145,15 @@ func NewEventsCollector(
 	timeLocation, _ := time.LoadLocation("UTC")
 	now := time.Now().In(timeLocation)
 
-	return &EventsCollector{
+	res := &EventsCollector{
 		namespace:                             namespace,
 		environment:                           environment,
 		deployment:                            deployment,
 		eventsInfoMetric:                      eventsInfoMetric,
 		applicationCrashesTotalMetric:         applicationCrashesTotalMetric,
+		applicationCrashReasonsTotalMetric:    applicationCrashReasonsTotalMetric,
+		applicationActivityTotalMetric:        applicationActivityTotalMetric,
+		eventsTotalMetric:                     eventsTotalMetric,
 		eventsScrapesTotalMetric:              eventsScrapesTotalMetric,
 		eventsScrapeErrorsTotalMetric:         eventsScrapeErrorsTotalMetric,
 		lastEventsScrapeErrorMetric:           lastEventsScrapeErrorMetric,
@@ -118,7 +162,63 @@ func NewEventsCollector(
 		lastCheckFilter:                       now,
 		timeLocation:                          timeLocation,
 		countedCrashEvents:                    map[string]struct{}{},
+		countedEvents:                         map[string]struct{}{},
+		store:                                 store,
+	}
+	res.loadState()
+	return res
+}
+
+// loadState
+// 1. resume from the last scrape of a previous run, events created while the exporter
+// was down are fetched and counted only once
+func (c *EventsCollector) loadState() {
+	if c.store == nil {
+		return
+	}
+	state, err := c.store.Load()
+	if err != nil {
+		log.WithError(err).Warn("unable to load events state, starting from scratch")
+		return
+	}
+	if state == nil {
+		return
+	}
+
+	// 1.
+	c.lastCheckFilter = state.LastCheck.In(c.timeLocation)
+	for _, guid := range state.CountedCrashEvents {
+		c.countedCrashEvents[guid] = struct{}{}
+	}
+	for _, guid := range state.CountedEvents {
+		c.countedEvents[guid] = struct{}{}
+	}
+}
+
+func (c *EventsCollector) saveState() {
+	if c.store == nil {
+		return
+	}
+	state := &EventsState{
+		LastCheck:          c.lastCheckFilter,
+		CountedCrashEvents: make([]string, 0, len(c.countedCrashEvents)),
+		CountedEvents:      make([]string, 0, len(c.countedEvents)),
+	}
+	for guid := range c.countedCrashEvents {
+		state.CountedCrashEvents = append(state.CountedCrashEvents, guid)
+	}
+	for guid := range c.countedEvents {
+		state.CountedEvents = append(state.CountedEvents, guid)
 	}
+	if err := c.store.Save(state); err != nil {
+		log.WithError(err).Warn("unable to save events state")
+	}
+}
+
+// LastCheck returns the time of the last successful events scrape, events are
+// fetched from this time to not miss any when scrapes are far apart
+func (c *EventsCollector) LastCheck() time.Time {
+	return c.lastCheckFilter
 }
 
 func (c *EventsCollector) Collect(objs *models.CFObjects, ch chan<- prometheus.Metric) {
@@ -129,9 +229,14 @@ func (c *EventsCollector) Collect(objs *models.CFObjects, ch chan<- prometheus.M
 	} else {
 		c.reportEventsMetrics(objs, ch)
 		c.reportCrashMetrics(objs)
+		c.reportEventsTotalMetrics(objs)
+		c.saveState()
 	}
 
 	c.applicationCrashesTotalMetric.Collect(ch)
+	c.applicationCrashReasonsTotalMetric.Collect(ch)
+	c.applicationActivityTotalMetric.Collect(ch)
+	c.eventsTotalMetric.Collect(ch)
 	c.eventsScrapeErrorsTotalMetric.Collect(ch)
 	c.eventsScrapesTotalMetric.Inc()
 	c.eventsScrapesTotalMetric.Collect(ch)
@@ -146,6 +251,9 @@ func (c *EventsCollector) Collect(objs *models.CFObjects, ch chan<- prometheus.M
 func (c *EventsCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.eventsInfoMetric.Describe(ch)
 	c.applicationCrashesTotalMetric.Describe(ch)
+	c.applicationCrashReasonsTotalMetric.Describe(ch)
+	c.applicationActivityTotalMetric.Describe(ch)
+	c.eventsTotalMetric.Describe(ch)
 	c.eventsScrapesTotalMetric.Describe(ch)
 	c.eventsScrapeErrorsTotalMetric.Describe(ch)
 	c.lastEventsScrapeErrorMetric.Describe(ch)
@@ -191,6 +299,7 @@ func (c *EventsCollector) reportEventsMetrics(objs *models.CFObjects, ch chan<-
 // reportCrashMetrics
 // 1. iterate application crash events, incrementing the counter once per unique event
 // 2. resolve names from the fetched objects when available
+// 3. exit descriptions are free text, only export their cause to keep labels bounded
 func (c *EventsCollector) reportCrashMetrics(objs *models.CFObjects) {
 	stillPresent := make(map[string]struct{})
 
@@ -204,23 +313,11 @@ func (c *EventsCollector) reportCrashMetrics(objs *models.CFObjects) {
 			continue
 		}
 
+		// 2.
 		applicationID := event.Target.GUID
-		applicationName := event.Target.Name
-		if app, ok := objs.Apps[applicationID]; ok {
-			applicationName = app.Name
-		}
-
 		organizationID := event.Org.GUID
-		organizationName := ""
-		if org, ok := objs.Orgs[organizationID]; ok {
-			organizationName = org.Name
-		}
-
 		spaceID := event.Space.GUID
-		spaceName := ""
-		if space, ok := objs.Spaces[spaceID]; ok {
-			spaceName = space.Name
-		}
+		applicationName, organizationName, spaceName := resolveEventNames(objs, event)
 
 		instance := ""
 		if raw, ok := event.Data["index"]; ok {
@@ -238,7 +335,145 @@ func (c *EventsCollector) reportCrashMetrics(objs *models.CFObjects) {
 			spaceName,
 			instance,
 		).Inc()
+
+		// 3.
+		reason, _ := event.Data["reason"].(string)
+		exitStatus := ""
+		if status, ok := event.Data["exit_status"].(float64); ok {
+			exitStatus = strconv.FormatInt(int64(status), 10)
+		}
+		exitDescription, _ := event.Data["exit_description"].(string)
+
+		c.applicationCrashReasonsTotalMetric.WithLabelValues(
+			applicationID,
+			applicationName,
+			organizationID,
+			organizationName,
+			spaceID,
+			spaceName,
+			reason,
+			exitStatus,
+			crashCause(exitDescription),
+		).Inc()
 	}
 
 	c.countedCrashEvents = stillPresent
 }
+
+// crashCause
+// 1. the container was killed for exceeding its memory limit
+// 2. the instance failed its startup or liveness health check
+// 3. the application process exited on its own, ie: panic or uncaught error
+func crashCause(description string) string {
+	description = strings.ToLower(description)
+	switch {
+	// 1.
+	case strings.Contains(description, "out of memory"):
+		return "oom"
+	// 2.
+	case strings.Contains(description, "never healthy"),
+		strings.Contains(description, "became unhealthy"),
+		strings.Contains(description, "health check"),
+		strings.Contains(description, "healthcheck"),
+		strings.Contains(description, "liveness"):
+		return "health_check"
+	// 3.
+	case strings.Contains(description, "exited with status"):
+		return "exited"
+	case description == "":
+		return ""
+	}
+	return "other"
+}
+
+// reportEventsTotalMetrics
+// 1. increment the counter once per unique event, fetched events overlap between scrapes
+// 2. count application activities from the same events, see applicationActivity
+// 3. only remember events still returned by the Cloud Controller to bound memory usage
+func (c *EventsCollector) reportEventsTotalMetrics(objs *models.CFObjects) {
+	stillPresent := make(map[string]struct{})
+
+	for guid, event := range objs.Events {
+		// 1.
+		stillPresent[guid] = struct{}{}
+		if _, counted := c.countedEvents[guid]; counted {
+			continue
+		}
+
+		c.eventsTotalMetric.WithLabelValues(
+			event.Type,
+			event.Org.GUID,
+			event.Space.GUID,
+			event.Actor.Type,
+		).Inc()
+
+		// 2.
+		activity := applicationActivity(event)
+		if activity == "" {
+			continue
+		}
+		applicationName, organizationName, spaceName := resolveEventNames(objs, event)
+		c.applicationActivityTotalMetric.WithLabelValues(
+			event.Target.GUID,
+			applicationName,
+			event.Org.GUID,
+			organizationName,
+			event.Space.GUID,
+			spaceName,
+			activity,
+		).Inc()
+	}
+
+	// 3.
+	c.countedEvents = stillPresent
+}
+
+// resolveEventNames returns the application, organization and space names of
+// an application event, from the fetched objects when available
+func resolveEventNames(objs *models.CFObjects, event models.Event) (string, string, string) {
+	applicationName := event.Target.Name
+	if app, ok := objs.Apps[event.Target.GUID]; ok {
+		applicationName = app.Name
+	}
+
+	organizationName := ""
+	if org, ok := objs.Orgs[event.Org.GUID]; ok {
+		organizationName = org.Name
+	}
+
+	spaceName := ""
+	if space, ok := objs.Spaces[event.Space.GUID]; ok {
+		spaceName = space.Name
+	}
+	return applicationName, organizationName, spaceName
+}
+
+// applicationActivity
+// 1. a new deployment, created by rolling or canary pushes and restarts
+// 2. a new droplet, created by every push and restage
+// 3. v2 clients scale through an application update carrying the new sizes
+func applicationActivity(event models.Event) string {
+	switch event.Type {
+	// 1.
+	case "audit.app.deployment.create":
+		return "deploy"
+	// 2.
+	case "audit.app.droplet.create":
+		return "stage"
+	case "audit.app.restage":
+		return "restage"
+	case "audit.app.restart", "audit.app.start":
+		return "restart"
+	case "audit.app.process.scale":
+		return "scale"
+	// 3.
+	case "audit.app.update":
+		request, _ := event.Data["request"].(map[string]interface{})
+		for _, key := range []string{"instances", "memory", "disk_quota"} {
+			if _, ok := request[key]; ok {
+				return "scale"
+			}
+		}
+	}
+	return ""
+}
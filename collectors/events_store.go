@@ -0,0 +1,18 @@
+package collectors
+
+import "time"
+
+// EventsState holds the progress of the events collector which must survive
+// exporter restarts for event derived counters to be counted exactly once
+type EventsState struct {
+	LastCheck          time.Time `json:"last_check"`
+	CountedCrashEvents []string  `json:"counted_crash_events"`
+	CountedEvents      []string  `json:"counted_events"`
+}
+
+// EventsStore persists the events collector state, Load returns a nil state
+// when nothing was saved yet
+type EventsStore interface {
+	Load() (*EventsState, error)
+	Save(state *EventsState) error
+}
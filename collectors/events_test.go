@@ -0,0 +1,137 @@
+package collectors
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/cf_exporter/v2/models"
+)
+
+func event(guid string, eventType string) models.Event {
+	return models.Event{
+		GUID:  guid,
+		Type:  eventType,
+		Actor: models.EventActor{Type: "user"},
+		Space: models.EventSpace{GUID: "space1-guid"},
+		Org:   models.EventOrg{GUID: "org1-guid"},
+	}
+}
+
+func eventsObjects(events ...models.Event) *models.CFObjects {
+	objs := models.NewCFObjects()
+	for _, event := range events {
+		objs.Events[event.GUID] = event
+	}
+	return objs
+}
+
+var _ = ginkgo.Describe("EventsCollector", func() {
+	ginkgo.Describe("reportEventsTotalMetrics", func() {
+		ginkgo.It("counts each event once across overlapping windows", func() {
+			collector := NewEventsCollector("cf", "env", "deployment", nil)
+
+			collector.reportEventsTotalMetrics(eventsObjects(event("event1-guid", "audit.app.update"), event("event2-guid", "audit.app.update")))
+			first := counterValue(collector.eventsTotalMetric, "audit.app.update", "org1-guid", "space1-guid", "user")
+			gomega.Ω(first).Should(gomega.Equal(float64(2)))
+
+			collector.reportEventsTotalMetrics(eventsObjects(event("event2-guid", "audit.app.update"), event("event3-guid", "audit.app.update")))
+			second := counterValue(collector.eventsTotalMetric, "audit.app.update", "org1-guid", "space1-guid", "user")
+			gomega.Ω(second).Should(gomega.Equal(float64(3)))
+
+			collector.reportEventsTotalMetrics(eventsObjects())
+			gomega.Ω(counterValue(collector.eventsTotalMetric, "audit.app.update", "org1-guid", "space1-guid", "user")).Should(gomega.BeNumerically(">=", second))
+		})
+	})
+
+	ginkgo.Describe("loadState", func() {
+		var dir string
+
+		ginkgo.BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "cf_exporter_events")
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+		})
+
+		ginkgo.AfterEach(func() {
+			gomega.Ω(os.RemoveAll(dir)).Should(gomega.Succeed())
+		})
+
+		ginkgo.It("does not count again the events seen before a restart", func() {
+			store := NewFileStore[EventsState](filepath.Join(dir, "events.json"))
+			objs := eventsObjects(event("event1-guid", "audit.app.update"), event("event2-guid", "app.crash"))
+
+			collector := NewEventsCollector("cf", "env", "deployment", store)
+			collector.reportCrashMetrics(objs)
+			collector.reportEventsTotalMetrics(objs)
+			collector.saveState()
+			gomega.Ω(counterValue(collector.eventsTotalMetric, "audit.app.update", "org1-guid", "space1-guid", "user")).Should(gomega.Equal(float64(1)))
+
+			restarted := NewEventsCollector("cf", "env", "deployment", store)
+			gomega.Ω(restarted.LastCheck()).Should(gomega.BeTemporally("==", collector.LastCheck()))
+			objs.Events["event3-guid"] = event("event3-guid", "audit.app.update")
+			restarted.reportCrashMetrics(objs)
+			restarted.reportEventsTotalMetrics(objs)
+
+			gomega.Ω(counterValue(restarted.eventsTotalMetric, "audit.app.update", "org1-guid", "space1-guid", "user")).Should(gomega.Equal(float64(1)))
+			gomega.Ω(counterValue(restarted.eventsTotalMetric, "app.crash", "org1-guid", "space1-guid", "user")).Should(gomega.Equal(float64(0)))
+			gomega.Ω(counterValue(restarted.applicationCrashesTotalMetric, "", "", "org1-guid", "", "space1-guid", "", "")).Should(gomega.Equal(float64(0)))
+		})
+	})
+})
+
+var _ = ginkgo.Describe("crashCause", func() {
+	cases := []struct {
+		description string
+		expected    string
+	}{
+		{"APP/PROC/WEB: Exited with status 137 (out of memory)", "oom"},
+		{"Instance never healthy after 1m0s: Failed to make TCP connection to port 8080", "health_check"},
+		{"Instance became unhealthy: Failed to make HTTP request", "health_check"},
+		{"failed health check", "health_check"},
+		{"healthcheck timed out", "health_check"},
+		{"liveness probe failed", "health_check"},
+		{"APP/PROC/WEB: Exited with status 1", "exited"},
+		{"stopped by the platform", "other"},
+		{"", ""},
+	}
+	for _, c := range cases {
+		c := c
+		ginkgo.It("finds the cause of '"+c.description+"'", func() {
+			gomega.Expect(crashCause(c.description)).To(gomega.Equal(c.expected))
+		})
+	}
+})
+
+var _ = ginkgo.Describe("applicationActivity", func() {
+	cases := []struct {
+		eventType string
+		request   map[string]interface{}
+		expected  string
+	}{
+		{"audit.app.deployment.create", nil, "deploy"},
+		{"audit.app.droplet.create", nil, "stage"},
+		{"audit.app.restage", nil, "restage"},
+		{"audit.app.restart", nil, "restart"},
+		{"audit.app.start", nil, "restart"},
+		{"audit.app.process.scale", nil, "scale"},
+		{"audit.app.update", map[string]interface{}{"instances": float64(2)}, "scale"},
+		{"audit.app.update", map[string]interface{}{"memory": float64(512)}, "scale"},
+		{"audit.app.update", map[string]interface{}{"disk_quota": float64(1024)}, "scale"},
+		{"audit.app.update", map[string]interface{}{"name": "renamed"}, ""},
+		{"audit.app.ssh-authorized", nil, ""},
+	}
+	for _, c := range cases {
+		c := c
+		ginkgo.It(fmt.Sprintf("maps %s with request %v", c.eventType, c.request), func() {
+			e := event("event1-guid", c.eventType)
+			if c.request != nil {
+				e.Data = map[string]interface{}{"request": c.request}
+			}
+			gomega.Expect(applicationActivity(e)).To(gomega.Equal(c.expected))
+		})
+	}
+})
@@ -0,0 +1,163 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/cf_exporter/v2/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type FeatureFlagsCollector struct {
+	namespace                                   string
+	environment                                 string
+	deployment                                  string
+	featureFlagEnabledMetric                    *prometheus.GaugeVec
+	featureFlagUpdatedAtMetric                  *prometheus.GaugeVec
+	featureFlagsScrapesTotalMetric              prometheus.Counter
+	featureFlagsScrapeErrorsTotalMetric         prometheus.Counter
+	lastFeatureFlagsScrapeErrorMetric           prometheus.Gauge
+	lastFeatureFlagsScrapeTimestampMetric       prometheus.Gauge
+	lastFeatureFlagsScrapeDurationSecondsMetric prometheus.Gauge
+}
+
+func NewFeatureFlagsCollector(
+	namespace string,
+	environment string,
+	deployment string,
+) *FeatureFlagsCollector {
+	featureFlagEnabledMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "feature_flag",
+			Name:        "enabled",
+			Help:        "Whether a Cloud Foundry Feature Flag is enabled (1 for enabled, 0 for disabled).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"feature_flag_name"},
+	)
+
+	featureFlagUpdatedAtMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "feature_flag",
+			Name:        "updated_at",
+			Help:        "Number of seconds since 1970 since a Cloud Foundry Feature Flag was last updated.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"feature_flag_name"},
+	)
+
+	featureFlagsScrapesTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "feature_flags_scrapes",
+			Name:        "total",
+			Help:        "Total number of scrapes for Cloud Foundry Feature Flags.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	featureFlagsScrapeErrorsTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "feature_flags_scrape_errors",
+			Name:        "total",
+			Help:        "Total number of scrape errors of Cloud Foundry Feature Flags.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastFeatureFlagsScrapeErrorMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_feature_flags_scrape_error",
+			Help:        "Whether the last scrape of Feature Flags metrics from Cloud Foundry resulted in an error (1 for error, 0 for success).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastFeatureFlagsScrapeTimestampMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_feature_flags_scrape_timestamp",
+			Help:        "Number of seconds since 1970 since last scrape of Feature Flags metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastFeatureFlagsScrapeDurationSecondsMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_feature_flags_scrape_duration_seconds",
+			Help:        "Duration of the last scrape of Feature Flags metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	return &FeatureFlagsCollector{
+		namespace:                                   namespace,
+		environment:                                 environment,
+		deployment:                                  deployment,
+		featureFlagEnabledMetric:                    featureFlagEnabledMetric,
+		featureFlagUpdatedAtMetric:                  featureFlagUpdatedAtMetric,
+		featureFlagsScrapesTotalMetric:              featureFlagsScrapesTotalMetric,
+		featureFlagsScrapeErrorsTotalMetric:         featureFlagsScrapeErrorsTotalMetric,
+		lastFeatureFlagsScrapeErrorMetric:           lastFeatureFlagsScrapeErrorMetric,
+		lastFeatureFlagsScrapeTimestampMetric:       lastFeatureFlagsScrapeTimestampMetric,
+		lastFeatureFlagsScrapeDurationSecondsMetric: lastFeatureFlagsScrapeDurationSecondsMetric,
+	}
+}
+
+func (c FeatureFlagsCollector) Collect(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	errorMetric := float64(0)
+	if objs.Error != nil {
+		errorMetric = float64(1)
+		c.featureFlagsScrapeErrorsTotalMetric.Inc()
+	} else {
+		c.reportFeatureFlagsMetrics(objs, ch)
+	}
+
+	c.featureFlagsScrapeErrorsTotalMetric.Collect(ch)
+	c.featureFlagsScrapesTotalMetric.Inc()
+	c.featureFlagsScrapesTotalMetric.Collect(ch)
+	c.lastFeatureFlagsScrapeErrorMetric.Set(errorMetric)
+	c.lastFeatureFlagsScrapeErrorMetric.Collect(ch)
+	c.lastFeatureFlagsScrapeTimestampMetric.Set(float64(time.Now().Unix()))
+	c.lastFeatureFlagsScrapeTimestampMetric.Collect(ch)
+	c.lastFeatureFlagsScrapeDurationSecondsMetric.Set(objs.Took)
+	c.lastFeatureFlagsScrapeDurationSecondsMetric.Collect(ch)
+}
+
+func (c FeatureFlagsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.featureFlagEnabledMetric.Describe(ch)
+	c.featureFlagUpdatedAtMetric.Describe(ch)
+	c.featureFlagsScrapesTotalMetric.Describe(ch)
+	c.featureFlagsScrapeErrorsTotalMetric.Describe(ch)
+	c.lastFeatureFlagsScrapeErrorMetric.Describe(ch)
+	c.lastFeatureFlagsScrapeTimestampMetric.Describe(ch)
+	c.lastFeatureFlagsScrapeDurationSecondsMetric.Describe(ch)
+}
+
+func (c FeatureFlagsCollector) reportFeatureFlagsMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	c.featureFlagEnabledMetric.Reset()
+	c.featureFlagUpdatedAtMetric.Reset()
+
+	for _, flag := range objs.FeatureFlags {
+		c.featureFlagEnabledMetric.WithLabelValues(
+			flag.Name,
+		).Set(BoolToFloat(&flag.Enabled))
+
+		// flags never changed since their default have no update time
+		if !flag.UpdatedAt.IsZero() {
+			c.featureFlagUpdatedAtMetric.WithLabelValues(
+				flag.Name,
+			).Set(float64(flag.UpdatedAt.Unix()))
+		}
+	}
+
+	c.featureFlagEnabledMetric.Collect(ch)
+	c.featureFlagUpdatedAtMetric.Collect(ch)
+}
@@ -3,6 +3,7 @@ package collectors
 import (
 	"time"
 
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
 	"github.com/cloudfoundry/cf_exporter/v2/models"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -12,6 +13,11 @@ type IsolationSegmentsCollector struct {
 	environment                                      string
 	deployment                                       string
 	isolationSegmentInfoMetric                       *prometheus.GaugeVec
+	isolationSegmentOrgMetric                        *prometheus.GaugeVec
+	isolationSegmentDefaultMetric                    *prometheus.GaugeVec
+	isolationSegmentSpaceMetric                      *prometheus.GaugeVec
+	isolationSegmentAppsMetric                       *prometheus.GaugeVec
+	isolationSegmentMemoryMetric                     *prometheus.GaugeVec
 	isolationSegmentsScrapesTotalMetric              prometheus.Counter
 	isolationSegmentsScrapeErrorsTotalMetric         prometheus.Counter
 	lastIsolationSegmentsScrapeErrorMetric           prometheus.Gauge
@@ -35,6 +41,61 @@ func NewIsolationSegmentsCollector(
 		[]string{"isolation_segment_id", "isolation_segment_name"},
 	)
 
+	isolationSegmentOrgMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "isolation_segment",
+			Name:        "organization",
+			Help:        "Labeled Cloud Foundry Organization entitled to an Isolation Segment with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"isolation_segment_id", "isolation_segment_name", "organization_id", "organization_name"},
+	)
+
+	isolationSegmentDefaultMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "isolation_segment",
+			Name:        "organization_default",
+			Help:        "Labeled Cloud Foundry Organization using an Isolation Segment as default with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"isolation_segment_id", "isolation_segment_name", "organization_id", "organization_name"},
+	)
+
+	isolationSegmentSpaceMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "isolation_segment",
+			Name:        "space",
+			Help:        "Labeled Cloud Foundry Space assigned to an Isolation Segment with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"isolation_segment_id", "isolation_segment_name", "space_id", "space_name", "organization_id", "organization_name"},
+	)
+
+	isolationSegmentAppsMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "isolation_segment",
+			Name:        "applications",
+			Help:        "Number of Cloud Foundry Applications placed on an Isolation Segment.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"isolation_segment_id", "isolation_segment_name"},
+	)
+
+	isolationSegmentMemoryMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "isolation_segment",
+			Name:        "memory_mb",
+			Help:        "Total memory in MB of the started Cloud Foundry Applications instances placed on an Isolation Segment.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"isolation_segment_id", "isolation_segment_name"},
+	)
+
 	isolationSegmentsScrapesTotalMetric := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace:   namespace,
@@ -90,6 +151,11 @@ func NewIsolationSegmentsCollector(
 		environment:                                      environment,
 		deployment:                                       deployment,
 		isolationSegmentInfoMetric:                       isolationSegmentInfoMetric,
+		isolationSegmentOrgMetric:                        isolationSegmentOrgMetric,
+		isolationSegmentDefaultMetric:                    isolationSegmentDefaultMetric,
+		isolationSegmentSpaceMetric:                      isolationSegmentSpaceMetric,
+		isolationSegmentAppsMetric:                       isolationSegmentAppsMetric,
+		isolationSegmentMemoryMetric:                     isolationSegmentMemoryMetric,
 		isolationSegmentsScrapesTotalMetric:              isolationSegmentsScrapesTotalMetric,
 		isolationSegmentsScrapeErrorsTotalMetric:         isolationSegmentsScrapeErrorsTotalMetric,
 		lastIsolationSegmentsScrapeErrorMetric:           lastIsolationSegmentsScrapeErrorMetric,
@@ -120,6 +186,11 @@ func (c IsolationSegmentsCollector) Collect(objs *models.CFObjects, ch chan<- pr
 
 func (c IsolationSegmentsCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.isolationSegmentInfoMetric.Describe(ch)
+	c.isolationSegmentOrgMetric.Describe(ch)
+	c.isolationSegmentDefaultMetric.Describe(ch)
+	c.isolationSegmentSpaceMetric.Describe(ch)
+	c.isolationSegmentAppsMetric.Describe(ch)
+	c.isolationSegmentMemoryMetric.Describe(ch)
 	c.isolationSegmentsScrapesTotalMetric.Describe(ch)
 	c.isolationSegmentsScrapeErrorsTotalMetric.Describe(ch)
 	c.lastIsolationSegmentsScrapeErrorMetric.Describe(ch)
@@ -127,14 +198,105 @@ func (c IsolationSegmentsCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.lastIsolationSegmentsScrapeDurationSecondsMetric.Describe(ch)
 }
 
+// reportIsolationSegmentsMetrics
+//  1. an application runs on the segment of its space, on the default segment
+//     of its organization otherwise, and on the shared segment when none is set
+//  2. only started applications consume memory
 func (c IsolationSegmentsCollector) reportIsolationSegmentsMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
 	c.isolationSegmentInfoMetric.Reset()
+	c.isolationSegmentOrgMetric.Reset()
+	c.isolationSegmentDefaultMetric.Reset()
+	c.isolationSegmentSpaceMetric.Reset()
+	c.isolationSegmentAppsMetric.Reset()
+	c.isolationSegmentMemoryMetric.Reset()
 
+	sharedGUID := ""
+	spaceSegments := map[string]string{}
 	for _, s := range objs.Segments {
 		c.isolationSegmentInfoMetric.WithLabelValues(
 			s.GUID,
 			s.Name,
 		).Set(float64(1))
+
+		if s.Name == "shared" {
+			sharedGUID = s.GUID
+		}
+
+		placement := objs.SegmentPlacements[s.GUID]
+		for _, orgGUID := range placement.OrganizationGUIDs {
+			c.isolationSegmentOrgMetric.WithLabelValues(
+				s.GUID,
+				s.Name,
+				orgGUID,
+				objs.Orgs[orgGUID].Name,
+			).Set(float64(1))
+		}
+		for _, spaceGUID := range placement.SpaceGUIDs {
+			spaceSegments[spaceGUID] = s.GUID
+			space := objs.Spaces[spaceGUID]
+			orgGUID := space.Relationships[constant.RelationshipTypeOrganization].GUID
+			c.isolationSegmentSpaceMetric.WithLabelValues(
+				s.GUID,
+				s.Name,
+				spaceGUID,
+				space.Name,
+				orgGUID,
+				objs.Orgs[orgGUID].Name,
+			).Set(float64(1))
+		}
+	}
+
+	for orgGUID, segmentGUID := range objs.OrgDefaultSegments {
+		c.isolationSegmentDefaultMetric.WithLabelValues(
+			segmentGUID,
+			objs.Segments[segmentGUID].Name,
+			orgGUID,
+			objs.Orgs[orgGUID].Name,
+		).Set(float64(1))
 	}
+
+	apps := map[string]int{}
+	memory := map[string]uint64{}
+	for _, app := range objs.Apps {
+		// 1.
+		spaceGUID := app.Relationships[constant.RelationshipTypeSpace].GUID
+		segmentGUID, ok := spaceSegments[spaceGUID]
+		if !ok {
+			orgGUID := objs.Spaces[spaceGUID].Relationships[constant.RelationshipTypeOrganization].GUID
+			segmentGUID, ok = objs.OrgDefaultSegments[orgGUID]
+		}
+		if !ok {
+			segmentGUID = sharedGUID
+		}
+		if _, ok := objs.Segments[segmentGUID]; !ok {
+			continue
+		}
+
+		apps[segmentGUID]++
+		// 2.
+		if app.State != constant.ApplicationStarted {
+			continue
+		}
+		for _, process := range objs.AppProcesses[app.GUID] {
+			memory[segmentGUID] += uint64(process.Instances.Value) * process.MemoryInMB.Value
+		}
+	}
+
+	for guid, segment := range objs.Segments {
+		c.isolationSegmentAppsMetric.WithLabelValues(
+			guid,
+			segment.Name,
+		).Set(float64(apps[guid]))
+		c.isolationSegmentMemoryMetric.WithLabelValues(
+			guid,
+			segment.Name,
+		).Set(float64(memory[guid]))
+	}
+
 	c.isolationSegmentInfoMetric.Collect(ch)
+	c.isolationSegmentOrgMetric.Collect(ch)
+	c.isolationSegmentDefaultMetric.Collect(ch)
+	c.isolationSegmentSpaceMetric.Collect(ch)
+	c.isolationSegmentAppsMetric.Collect(ch)
+	c.isolationSegmentMemoryMetric.Collect(ch)
 }
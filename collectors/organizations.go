@@ -21,6 +21,7 @@ type OrganizationsCollector struct {
 	organizationTotalAppInstancesQuotaMetric       *prometheus.GaugeVec
 	organizationTotalAppTasksQuotaMetric           *prometheus.GaugeVec
 	organizationTotalMemoryMbQuotaMetric           *prometheus.GaugeVec
+	organizationTotalLogRateLimitQuotaMetric       *prometheus.GaugeVec
 	organizationTotalPrivateDomainsQuotaMetric     *prometheus.GaugeVec
 	organizationTotalReservedRoutePortsQuotaMetric *prometheus.GaugeVec
 	organizationTotalRoutesQuotaMetric             *prometheus.GaugeVec
@@ -104,6 +105,17 @@ func NewOrganizationsCollector(
 		[]string{"organization_id", "organization_name"},
 	)
 
+	organizationTotalLogRateLimitQuotaMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "organization",
+			Name:        "total_log_rate_limit_bytes_per_second_quota",
+			Help:        "Total log rate limit (bytes per second) a Cloud Foundry Organization can have.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"organization_id", "organization_name"},
+	)
+
 	organizationTotalPrivateDomainsQuotaMetric := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace:   namespace,
@@ -219,6 +231,7 @@ func NewOrganizationsCollector(
 		organizationTotalAppInstancesQuotaMetric:       organizationTotalAppInstancesQuotaMetric,
 		organizationTotalAppTasksQuotaMetric:           organizationTotalAppTasksQuotaMetric,
 		organizationTotalMemoryMbQuotaMetric:           organizationTotalMemoryMbQuotaMetric,
+		organizationTotalLogRateLimitQuotaMetric:       organizationTotalLogRateLimitQuotaMetric,
 		organizationTotalPrivateDomainsQuotaMetric:     organizationTotalPrivateDomainsQuotaMetric,
 		organizationTotalReservedRoutePortsQuotaMetric: organizationTotalReservedRoutePortsQuotaMetric,
 		organizationTotalRoutesQuotaMetric:             organizationTotalRoutesQuotaMetric,
@@ -263,6 +276,7 @@ func (c OrganizationsCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.organizationTotalAppInstancesQuotaMetric.Describe(ch)
 	c.organizationTotalAppTasksQuotaMetric.Describe(ch)
 	c.organizationTotalMemoryMbQuotaMetric.Describe(ch)
+	c.organizationTotalLogRateLimitQuotaMetric.Describe(ch)
 	c.organizationTotalPrivateDomainsQuotaMetric.Describe(ch)
 	c.organizationTotalReservedRoutePortsQuotaMetric.Describe(ch)
 	c.organizationTotalRoutesQuotaMetric.Describe(ch)
@@ -303,6 +317,10 @@ func (c OrganizationsCollector) reportOrg(org resources.Organization, objs *mode
 			org.GUID,
 			org.Name,
 		).Set(NullIntToFloat(quota.Apps.TotalMemory))
+		c.organizationTotalLogRateLimitQuotaMetric.WithLabelValues(
+			org.GUID,
+			org.Name,
+		).Set(NullIntToFloat(quota.Apps.LogRateLimit))
 		c.organizationTotalPrivateDomainsQuotaMetric.WithLabelValues(
 			org.GUID,
 			org.Name,
@@ -345,6 +363,7 @@ func (c OrganizationsCollector) reportOrganizationsMetrics(objs *models.CFObject
 	c.organizationTotalAppInstancesQuotaMetric.Reset()
 	c.organizationTotalAppTasksQuotaMetric.Reset()
 	c.organizationTotalMemoryMbQuotaMetric.Reset()
+	c.organizationTotalLogRateLimitQuotaMetric.Reset()
 	c.organizationTotalPrivateDomainsQuotaMetric.Reset()
 	c.organizationTotalReservedRoutePortsQuotaMetric.Reset()
 	c.organizationTotalRoutesQuotaMetric.Reset()
@@ -366,6 +385,7 @@ func (c OrganizationsCollector) reportOrganizationsMetrics(objs *models.CFObject
 	c.organizationTotalAppInstancesQuotaMetric.Collect(ch)
 	c.organizationTotalAppTasksQuotaMetric.Collect(ch)
 	c.organizationTotalMemoryMbQuotaMetric.Collect(ch)
+	c.organizationTotalLogRateLimitQuotaMetric.Collect(ch)
 	c.organizationTotalPrivateDomainsQuotaMetric.Collect(ch)
 	c.organizationTotalReservedRoutePortsQuotaMetric.Collect(ch)
 	c.organizationTotalRoutesQuotaMetric.Collect(ch)
@@ -0,0 +1,265 @@
+package collectors
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
+	"github.com/cloudfoundry/cf_exporter/v2/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type RolesCollector struct {
+	namespace                            string
+	environment                          string
+	deployment                           string
+	organizationRolesMetric              *prometheus.GaugeVec
+	spaceRolesMetric                     *prometheus.GaugeVec
+	userWithoutRolesMetric               *prometheus.GaugeVec
+	organizationWithoutManagerMetric     *prometheus.GaugeVec
+	rolesScrapesTotalMetric              prometheus.Counter
+	rolesScrapeErrorsTotalMetric         prometheus.Counter
+	lastRolesScrapeErrorMetric           prometheus.Gauge
+	lastRolesScrapeTimestampMetric       prometheus.Gauge
+	lastRolesScrapeDurationSecondsMetric prometheus.Gauge
+}
+
+func NewRolesCollector(
+	namespace string,
+	environment string,
+	deployment string,
+) *RolesCollector {
+	organizationRolesMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "organization",
+			Name:        "roles",
+			Help:        "Number of Cloud Foundry Organization Roles of a type.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"organization_id", "organization_name", "role_type"},
+	)
+
+	spaceRolesMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "space",
+			Name:        "roles",
+			Help:        "Number of Cloud Foundry Space Roles of a type.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"space_id", "space_name", "organization_id", "organization_name", "role_type"},
+	)
+
+	userWithoutRolesMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "user",
+			Name:        "without_roles",
+			Help:        "Cloud Foundry User without any Organization or Space Role with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"user_id", "user_name", "origin"},
+	)
+
+	organizationWithoutManagerMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "organization",
+			Name:        "without_manager",
+			Help:        "Cloud Foundry Organization without any Organization Manager with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"organization_id", "organization_name"},
+	)
+
+	rolesScrapesTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "roles_scrapes",
+			Name:        "total",
+			Help:        "Total number of scrapes for Cloud Foundry Roles.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	rolesScrapeErrorsTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "roles_scrape_errors",
+			Name:        "total",
+			Help:        "Total number of scrape errors of Cloud Foundry Roles.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastRolesScrapeErrorMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_roles_scrape_error",
+			Help:        "Whether the last scrape of Roles metrics from Cloud Foundry resulted in an error (1 for error, 0 for success).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastRolesScrapeTimestampMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_roles_scrape_timestamp",
+			Help:        "Number of seconds since 1970 since last scrape of Roles metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastRolesScrapeDurationSecondsMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_roles_scrape_duration_seconds",
+			Help:        "Duration of the last scrape of Roles metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	return &RolesCollector{
+		namespace:                            namespace,
+		environment:                          environment,
+		deployment:                           deployment,
+		organizationRolesMetric:              organizationRolesMetric,
+		spaceRolesMetric:                     spaceRolesMetric,
+		userWithoutRolesMetric:               userWithoutRolesMetric,
+		organizationWithoutManagerMetric:     organizationWithoutManagerMetric,
+		rolesScrapesTotalMetric:              rolesScrapesTotalMetric,
+		rolesScrapeErrorsTotalMetric:         rolesScrapeErrorsTotalMetric,
+		lastRolesScrapeErrorMetric:           lastRolesScrapeErrorMetric,
+		lastRolesScrapeTimestampMetric:       lastRolesScrapeTimestampMetric,
+		lastRolesScrapeDurationSecondsMetric: lastRolesScrapeDurationSecondsMetric,
+	}
+}
+
+func (c RolesCollector) Collect(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	errorMetric := float64(0)
+	if objs.Error != nil {
+		errorMetric = float64(1)
+		c.rolesScrapeErrorsTotalMetric.Inc()
+	} else {
+		c.reportRolesMetrics(objs, ch)
+	}
+
+	c.rolesScrapeErrorsTotalMetric.Collect(ch)
+	c.rolesScrapesTotalMetric.Inc()
+	c.rolesScrapesTotalMetric.Collect(ch)
+	c.lastRolesScrapeErrorMetric.Set(errorMetric)
+	c.lastRolesScrapeErrorMetric.Collect(ch)
+	c.lastRolesScrapeTimestampMetric.Set(float64(time.Now().Unix()))
+	c.lastRolesScrapeTimestampMetric.Collect(ch)
+	c.lastRolesScrapeDurationSecondsMetric.Set(objs.Took)
+	c.lastRolesScrapeDurationSecondsMetric.Collect(ch)
+}
+
+func (c RolesCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.organizationRolesMetric.Describe(ch)
+	c.spaceRolesMetric.Describe(ch)
+	c.userWithoutRolesMetric.Describe(ch)
+	c.organizationWithoutManagerMetric.Describe(ch)
+	c.rolesScrapesTotalMetric.Describe(ch)
+	c.rolesScrapeErrorsTotalMetric.Describe(ch)
+	c.lastRolesScrapeErrorMetric.Describe(ch)
+	c.lastRolesScrapeTimestampMetric.Describe(ch)
+	c.lastRolesScrapeDurationSecondsMetric.Describe(ch)
+}
+
+var (
+	organizationRoleTypes = []constant.RoleType{
+		constant.OrgUserRole,
+		constant.OrgAuditorRole,
+		constant.OrgManagerRole,
+		constant.OrgBillingManagerRole,
+	}
+	spaceRoleTypes = []constant.RoleType{
+		constant.SpaceDeveloperRole,
+		constant.SpaceAuditorRole,
+		constant.SpaceManagerRole,
+		constant.SpaceSupporterRole,
+	}
+)
+
+// reportRolesMetrics
+//  1. report every role type, a missing role is counted as zero
+//  2. a user is kept in the Cloud Controller when its last role is removed,
+//     these are candidates for clean up in access reviews
+func (c RolesCollector) reportRolesMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	c.organizationRolesMetric.Reset()
+	c.spaceRolesMetric.Reset()
+	c.userWithoutRolesMetric.Reset()
+	c.organizationWithoutManagerMetric.Reset()
+
+	orgRoles := map[string]map[constant.RoleType]int{}
+	spaceRoles := map[string]map[constant.RoleType]int{}
+	usersWithRoles := map[string]bool{}
+	for _, role := range objs.Roles {
+		usersWithRoles[role.UserGUID] = true
+		if role.OrgGUID != "" {
+			if _, ok := orgRoles[role.OrgGUID]; !ok {
+				orgRoles[role.OrgGUID] = map[constant.RoleType]int{}
+			}
+			orgRoles[role.OrgGUID][role.Type]++
+		}
+		if role.SpaceGUID != "" {
+			if _, ok := spaceRoles[role.SpaceGUID]; !ok {
+				spaceRoles[role.SpaceGUID] = map[constant.RoleType]int{}
+			}
+			spaceRoles[role.SpaceGUID][role.Type]++
+		}
+	}
+
+	for _, org := range objs.Orgs {
+		// 1.
+		for _, roleType := range organizationRoleTypes {
+			c.organizationRolesMetric.WithLabelValues(
+				org.GUID,
+				org.Name,
+				string(roleType),
+			).Set(float64(orgRoles[org.GUID][roleType]))
+		}
+
+		if orgRoles[org.GUID][constant.OrgManagerRole] == 0 {
+			c.organizationWithoutManagerMetric.WithLabelValues(
+				org.GUID,
+				org.Name,
+			).Set(float64(1))
+		}
+	}
+
+	for _, space := range objs.Spaces {
+		orgGUID := space.Relationships[constant.RelationshipTypeOrganization].GUID
+		// 1.
+		for _, roleType := range spaceRoleTypes {
+			c.spaceRolesMetric.WithLabelValues(
+				space.GUID,
+				space.Name,
+				orgGUID,
+				objs.Orgs[orgGUID].Name,
+				string(roleType),
+			).Set(float64(spaceRoles[space.GUID][roleType]))
+		}
+	}
+
+	// 2.
+	for _, user := range objs.Users {
+		if usersWithRoles[user.GUID] {
+			continue
+		}
+		c.userWithoutRolesMetric.WithLabelValues(
+			user.GUID,
+			user.Username,
+			user.Origin,
+		).Set(float64(1))
+	}
+
+	c.organizationRolesMetric.Collect(ch)
+	c.spaceRolesMetric.Collect(ch)
+	c.userWithoutRolesMetric.Collect(ch)
+	c.organizationWithoutManagerMetric.Collect(ch)
+}
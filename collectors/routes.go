@@ -1,8 +1,10 @@
 package collectors
 
 import (
+	"strconv"
 	"time"
 
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
 	"github.com/cloudfoundry/cf_exporter/v2/models"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -12,6 +14,8 @@ type RoutesCollector struct {
 	environment                           string
 	deployment                            string
 	routeInfoMetric                       *prometheus.GaugeVec
+	routeDestinationInfoMetric            *prometheus.GaugeVec
+	routeDestinationsMetric               *prometheus.GaugeVec
 	routesScrapesTotalMetric              prometheus.Counter
 	routesScrapeErrorsTotalMetric         prometheus.Counter
 	lastRoutesScrapeErrorMetric           prometheus.Gauge
@@ -32,7 +36,29 @@ func NewRoutesCollector(
 			Help:        "Labeled Cloud Foundry Route information with a constant '1' value.",
 			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
 		},
-		[]string{"route_id", "route_host", "route_path", "domain_id", "space_id", "service_instance_id"},
+		[]string{"route_id", "route_host", "route_path", "route_url", "route_protocol", "route_port", "domain_id", "domain_name", "space_id", "space_name", "organization_id", "organization_name", "service_instance_id"},
+	)
+
+	routeDestinationInfoMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "route",
+			Name:        "destination_info",
+			Help:        "Labeled Cloud Foundry Route destination information with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"route_id", "destination_id", "application_id", "application_name", "process_type", "port", "protocol", "weight"},
+	)
+
+	routeDestinationsMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "route",
+			Name:        "destinations",
+			Help:        "Number of destinations of a Cloud Foundry Route.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"route_id"},
 	)
 
 	routesScrapesTotalMetric := prometheus.NewCounter(
@@ -90,6 +116,8 @@ func NewRoutesCollector(
 		environment:                           environment,
 		deployment:                            deployment,
 		routeInfoMetric:                       routeInfoMetric,
+		routeDestinationInfoMetric:            routeDestinationInfoMetric,
+		routeDestinationsMetric:               routeDestinationsMetric,
 		routesScrapesTotalMetric:              routesScrapesTotalMetric,
 		routesScrapeErrorsTotalMetric:         routesScrapeErrorsTotalMetric,
 		lastRoutesScrapeErrorMetric:           lastRoutesScrapeErrorMetric,
@@ -119,6 +147,8 @@ func (c RoutesCollector) Collect(objs *models.CFObjects, ch chan<- prometheus.Me
 
 func (c RoutesCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.routeInfoMetric.Describe(ch)
+	c.routeDestinationInfoMetric.Describe(ch)
+	c.routeDestinationsMetric.Describe(ch)
 	c.routesScrapesTotalMetric.Describe(ch)
 	c.routesScrapeErrorsTotalMetric.Describe(ch)
 	c.lastRoutesScrapeErrorMetric.Describe(ch)
@@ -126,23 +156,98 @@ func (c RoutesCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.lastRoutesScrapeDurationSecondsMetric.Describe(ch)
 }
 
+// reportRoutesMetrics
+//  1. resolve domain, space and organization names when available
+//  2. url is given by the cloud controller, build it from host, domain and
+//     path otherwise
+//  3. port is only set for tcp routes
 func (c RoutesCollector) reportRoutesMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
 	c.routeInfoMetric.Reset()
+	c.routeDestinationInfoMetric.Reset()
+	c.routeDestinationsMetric.Reset()
 
 	for _, route := range objs.Routes {
 		serviceGUID := ""
 		if binding, ok := objs.RoutesBindings[route.GUID]; ok {
 			serviceGUID = binding.ServiceInstanceGUID
 		}
+
+		// 1.
+		domainName := ""
+		if domain, ok := objs.Domains[route.DomainGUID]; ok {
+			domainName = domain.Name
+		}
+		spaceName := ""
+		organizationID := ""
+		organizationName := ""
+		if space, ok := objs.Spaces[route.SpaceGUID]; ok {
+			spaceName = space.Name
+			organizationID = space.Relationships[constant.RelationshipTypeOrganization].GUID
+			if org, ok := objs.Orgs[organizationID]; ok {
+				organizationName = org.Name
+			}
+		}
+
+		// 2.
+		url := route.URL
+		if url == "" && domainName != "" {
+			url = domainName + route.Path
+			if route.Host != "" {
+				url = route.Host + "." + url
+			}
+		}
+
+		// 3.
+		port := ""
+		if route.Port != 0 {
+			port = strconv.Itoa(route.Port)
+		}
+
 		c.routeInfoMetric.WithLabelValues(
 			route.GUID,
 			route.Host,
 			route.Path,
+			url,
+			route.Protocol,
+			port,
 			route.DomainGUID,
+			domainName,
 			route.SpaceGUID,
+			spaceName,
+			organizationID,
+			organizationName,
 			serviceGUID,
 		).Set(float64(1))
+
+		for _, destination := range route.Destinations {
+			applicationName := ""
+			if app, ok := objs.Apps[destination.App.GUID]; ok {
+				applicationName = app.Name
+			}
+
+			weight := ""
+			if destination.Weight.IsSet {
+				weight = strconv.Itoa(destination.Weight.Value)
+			}
+
+			c.routeDestinationInfoMetric.WithLabelValues(
+				route.GUID,
+				destination.GUID,
+				destination.App.GUID,
+				applicationName,
+				destination.App.Process.Type,
+				strconv.Itoa(destination.Port),
+				destination.Protocol,
+				weight,
+			).Set(float64(1))
+		}
+
+		c.routeDestinationsMetric.WithLabelValues(
+			route.GUID,
+		).Set(float64(len(route.Destinations)))
 	}
 
 	c.routeInfoMetric.Collect(ch)
+	c.routeDestinationInfoMetric.Collect(ch)
+	c.routeDestinationsMetric.Collect(ch)
 }
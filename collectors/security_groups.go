@@ -1,6 +1,7 @@
 package collectors
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/cloudfoundry/cf_exporter/v2/models"
@@ -12,6 +13,9 @@ type SecurityGroupsCollector struct {
 	environment                                   string
 	deployment                                    string
 	securityGroupInfoMetric                       *prometheus.GaugeVec
+	securityGroupRuleMetric                       *prometheus.GaugeVec
+	securityGroupSpaceMetric                      *prometheus.GaugeVec
+	securityGroupGlobalMetric                     *prometheus.GaugeVec
 	securityGroupsScrapesTotalMetric              prometheus.Counter
 	securityGroupsScrapeErrorsTotalMetric         prometheus.Counter
 	lastSecurityGroupsScrapeErrorMetric           prometheus.Gauge
@@ -35,6 +39,39 @@ func NewSecurityGroupsCollector(
 		[]string{"security_group_id", "security_group_name"},
 	)
 
+	securityGroupRuleMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "security_group",
+			Name:        "rule",
+			Help:        "Labeled Cloud Foundry Security Group rule with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"security_group_id", "security_group_name", "protocol", "destination", "ports", "type", "code", "log"},
+	)
+
+	securityGroupSpaceMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "security_group",
+			Name:        "space",
+			Help:        "Labeled Cloud Foundry Security Group binding to a space for a lifecycle (running or staging) with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"security_group_id", "security_group_name", "space_id", "lifecycle"},
+	)
+
+	securityGroupGlobalMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "security_group",
+			Name:        "globally_enabled",
+			Help:        "Whether a Cloud Foundry Security Group is globally enabled for a lifecycle (running or staging) (1 for enabled, 0 otherwise).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"security_group_id", "security_group_name", "lifecycle"},
+	)
+
 	securityGroupsScrapesTotalMetric := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace:   namespace,
@@ -90,6 +127,9 @@ func NewSecurityGroupsCollector(
 		environment:                                   environment,
 		deployment:                                    deployment,
 		securityGroupInfoMetric:                       securityGroupInfoMetric,
+		securityGroupRuleMetric:                       securityGroupRuleMetric,
+		securityGroupSpaceMetric:                      securityGroupSpaceMetric,
+		securityGroupGlobalMetric:                     securityGroupGlobalMetric,
 		securityGroupsScrapesTotalMetric:              securityGroupsScrapesTotalMetric,
 		securityGroupsScrapeErrorsTotalMetric:         securityGroupsScrapeErrorsTotalMetric,
 		lastSecurityGroupsScrapeErrorMetric:           lastSecurityGroupsScrapeErrorMetric,
@@ -120,6 +160,9 @@ func (c SecurityGroupsCollector) Collect(objs *models.CFObjects, ch chan<- prome
 
 func (c SecurityGroupsCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.securityGroupInfoMetric.Describe(ch)
+	c.securityGroupRuleMetric.Describe(ch)
+	c.securityGroupSpaceMetric.Describe(ch)
+	c.securityGroupGlobalMetric.Describe(ch)
 	c.securityGroupsScrapesTotalMetric.Describe(ch)
 	c.securityGroupsScrapeErrorsTotalMetric.Describe(ch)
 	c.lastSecurityGroupsScrapeErrorMetric.Describe(ch)
@@ -127,14 +170,77 @@ func (c SecurityGroupsCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.lastSecurityGroupsScrapeDurationSecondsMetric.Describe(ch)
 }
 
+// reportSecurityGroupsMetrics
+//  1. optional rule attributes are reported as empty labels when unset
 func (c SecurityGroupsCollector) reportSecurityGroupsMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
 	c.securityGroupInfoMetric.Reset()
+	c.securityGroupRuleMetric.Reset()
+	c.securityGroupSpaceMetric.Reset()
+	c.securityGroupGlobalMetric.Reset()
 
 	for _, cSGroup := range objs.SecurityGroups {
 		c.securityGroupInfoMetric.WithLabelValues(
 			cSGroup.GUID,
 			cSGroup.Name,
 		).Set(float64(1))
+
+		// 1.
+		for _, rule := range cSGroup.Rules {
+			ports := ""
+			if rule.Ports != nil {
+				ports = *rule.Ports
+			}
+			icmpType := ""
+			if rule.Type != nil {
+				icmpType = strconv.Itoa(*rule.Type)
+			}
+			icmpCode := ""
+			if rule.Code != nil {
+				icmpCode = strconv.Itoa(*rule.Code)
+			}
+			c.securityGroupRuleMetric.WithLabelValues(
+				cSGroup.GUID,
+				cSGroup.Name,
+				rule.Protocol,
+				rule.Destination,
+				ports,
+				icmpType,
+				icmpCode,
+				strconv.FormatBool(rule.Log != nil && *rule.Log),
+			).Set(float64(1))
+		}
+
+		for _, spaceGUID := range cSGroup.RunningSpaceGUIDs {
+			c.securityGroupSpaceMetric.WithLabelValues(
+				cSGroup.GUID,
+				cSGroup.Name,
+				spaceGUID,
+				"running",
+			).Set(float64(1))
+		}
+		for _, spaceGUID := range cSGroup.StagingSpaceGUIDs {
+			c.securityGroupSpaceMetric.WithLabelValues(
+				cSGroup.GUID,
+				cSGroup.Name,
+				spaceGUID,
+				"staging",
+			).Set(float64(1))
+		}
+
+		c.securityGroupGlobalMetric.WithLabelValues(
+			cSGroup.GUID,
+			cSGroup.Name,
+			"running",
+		).Set(BoolToFloat(cSGroup.RunningGloballyEnabled))
+		c.securityGroupGlobalMetric.WithLabelValues(
+			cSGroup.GUID,
+			cSGroup.Name,
+			"staging",
+		).Set(BoolToFloat(cSGroup.StagingGloballyEnabled))
 	}
+
 	c.securityGroupInfoMetric.Collect(ch)
+	c.securityGroupRuleMetric.Collect(ch)
+	c.securityGroupSpaceMetric.Collect(ch)
+	c.securityGroupGlobalMetric.Collect(ch)
 }
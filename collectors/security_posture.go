@@ -0,0 +1,384 @@
+package collectors
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
+	"code.cloudfoundry.org/cli/v8/resources"
+	"github.com/cloudfoundry/cf_exporter/v2/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type SecurityPostureCollector struct {
+	namespace                                      string
+	environment                                    string
+	deployment                                     string
+	openSecurityGroupRuleMetric                    *prometheus.GaugeVec
+	sshEnabledSpaceMetric                          *prometheus.GaugeVec
+	sshEnabledApplicationMetric                    *prometheus.GaugeVec
+	orgWithoutQuotaMetric                          *prometheus.GaugeVec
+	spaceWithoutQuotaMetric                        *prometheus.GaugeVec
+	unsupportedStackMetric                         *prometheus.GaugeVec
+	staleServiceKeyMetric                          *prometheus.GaugeVec
+	securityPostureScrapesTotalMetric              prometheus.Counter
+	securityPostureScrapeErrorsTotalMetric         prometheus.Counter
+	lastSecurityPostureScrapeErrorMetric           prometheus.Gauge
+	lastSecurityPostureScrapeTimestampMetric       prometheus.Gauge
+	lastSecurityPostureScrapeDurationSecondsMetric prometheus.Gauge
+	serviceKeyMaxAge                               time.Duration
+	deprecatedStacks                               []string
+}
+
+func NewSecurityPostureCollector(
+	namespace string,
+	environment string,
+	deployment string,
+	serviceKeyMaxAge time.Duration,
+	deprecatedStacks []string,
+) *SecurityPostureCollector {
+	openSecurityGroupRuleMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "security_posture",
+			Name:        "open_security_group_rule",
+			Help:        "Cloud Foundry Security Group rule allowing any destination on all ports with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"security_group_id", "security_group_name", "protocol", "destination", "ports"},
+	)
+
+	sshEnabledSpaceMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "security_posture",
+			Name:        "ssh_enabled_space",
+			Help:        "Cloud Foundry Space allowing ssh access with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"space_id", "space_name", "organization_id", "organization_name"},
+	)
+
+	sshEnabledApplicationMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "security_posture",
+			Name:        "ssh_enabled_application",
+			Help:        "Cloud Foundry Application accessible with ssh with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"application_id", "application_name", "space_id", "space_name", "organization_id", "organization_name"},
+	)
+
+	orgWithoutQuotaMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "security_posture",
+			Name:        "organization_without_quota",
+			Help:        "Cloud Foundry Organization without quota or with an unlimited memory quota with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"organization_id", "organization_name", "reason"},
+	)
+
+	spaceWithoutQuotaMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "security_posture",
+			Name:        "space_without_quota",
+			Help:        "Cloud Foundry Space without quota or with an unlimited memory quota with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"space_id", "space_name", "organization_id", "organization_name", "reason"},
+	)
+
+	unsupportedStackMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "security_posture",
+			Name:        "application_unsupported_stack",
+			Help:        "Cloud Foundry Application running on a deprecated or unknown stack with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"application_id", "application_name", "space_id", "stack", "reason"},
+	)
+
+	staleServiceKeyMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "security_posture",
+			Name:        "stale_service_key",
+			Help:        "Cloud Foundry Service Key older than the service key maximum age with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"service_binding_id", "service_binding_name", "service_instance_id"},
+	)
+
+	securityPostureScrapesTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "security_posture_scrapes",
+			Name:        "total",
+			Help:        "Total number of scrapes for Cloud Foundry Security Posture.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	securityPostureScrapeErrorsTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "security_posture_scrape_errors",
+			Name:        "total",
+			Help:        "Total number of scrape error of Cloud Foundry Security Posture.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastSecurityPostureScrapeErrorMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_security_posture_scrape_error",
+			Help:        "Whether the last scrape of Security Posture metrics from Cloud Foundry resulted in an error (1 for error, 0 for success).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastSecurityPostureScrapeTimestampMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_security_posture_scrape_timestamp",
+			Help:        "Number of seconds since 1970 since last scrape of Security Posture metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastSecurityPostureScrapeDurationSecondsMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_security_posture_scrape_duration_seconds",
+			Help:        "Duration of the last scrape of Security Posture metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	return &SecurityPostureCollector{
+		namespace:                                      namespace,
+		environment:                                    environment,
+		deployment:                                     deployment,
+		openSecurityGroupRuleMetric:                    openSecurityGroupRuleMetric,
+		sshEnabledSpaceMetric:                          sshEnabledSpaceMetric,
+		sshEnabledApplicationMetric:                    sshEnabledApplicationMetric,
+		orgWithoutQuotaMetric:                          orgWithoutQuotaMetric,
+		spaceWithoutQuotaMetric:                        spaceWithoutQuotaMetric,
+		unsupportedStackMetric:                         unsupportedStackMetric,
+		staleServiceKeyMetric:                          staleServiceKeyMetric,
+		securityPostureScrapesTotalMetric:              securityPostureScrapesTotalMetric,
+		securityPostureScrapeErrorsTotalMetric:         securityPostureScrapeErrorsTotalMetric,
+		lastSecurityPostureScrapeErrorMetric:           lastSecurityPostureScrapeErrorMetric,
+		lastSecurityPostureScrapeTimestampMetric:       lastSecurityPostureScrapeTimestampMetric,
+		lastSecurityPostureScrapeDurationSecondsMetric: lastSecurityPostureScrapeDurationSecondsMetric,
+		serviceKeyMaxAge:                               serviceKeyMaxAge,
+		deprecatedStacks:                               deprecatedStacks,
+	}
+}
+
+func (c SecurityPostureCollector) Collect(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	errorMetric := float64(0)
+	if objs.Error != nil {
+		errorMetric = float64(1)
+		c.securityPostureScrapeErrorsTotalMetric.Inc()
+	} else {
+		c.reportSecurityPostureMetrics(objs, ch)
+	}
+
+	c.securityPostureScrapeErrorsTotalMetric.Collect(ch)
+	c.securityPostureScrapesTotalMetric.Inc()
+	c.securityPostureScrapesTotalMetric.Collect(ch)
+	c.lastSecurityPostureScrapeErrorMetric.Set(errorMetric)
+	c.lastSecurityPostureScrapeErrorMetric.Collect(ch)
+	c.lastSecurityPostureScrapeTimestampMetric.Set(float64(time.Now().Unix()))
+	c.lastSecurityPostureScrapeTimestampMetric.Collect(ch)
+	c.lastSecurityPostureScrapeDurationSecondsMetric.Set(objs.Took)
+	c.lastSecurityPostureScrapeDurationSecondsMetric.Collect(ch)
+}
+
+func (c SecurityPostureCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.openSecurityGroupRuleMetric.Describe(ch)
+	c.sshEnabledSpaceMetric.Describe(ch)
+	c.sshEnabledApplicationMetric.Describe(ch)
+	c.orgWithoutQuotaMetric.Describe(ch)
+	c.spaceWithoutQuotaMetric.Describe(ch)
+	c.unsupportedStackMetric.Describe(ch)
+	c.staleServiceKeyMetric.Describe(ch)
+	c.securityPostureScrapesTotalMetric.Describe(ch)
+	c.securityPostureScrapeErrorsTotalMetric.Describe(ch)
+	c.lastSecurityPostureScrapeErrorMetric.Describe(ch)
+	c.lastSecurityPostureScrapeTimestampMetric.Describe(ch)
+	c.lastSecurityPostureScrapeDurationSecondsMetric.Describe(ch)
+}
+
+// isOpenRule
+//  1. any IPv4 destination, as a CIDR or a range
+//  2. all protocols or all ports of a transport protocol
+func isOpenRule(rule resources.Rule) bool {
+	// 1.
+	if rule.Destination != "0.0.0.0/0" && rule.Destination != "0.0.0.0-255.255.255.255" {
+		return false
+	}
+	// 2.
+	if rule.Protocol == "all" {
+		return true
+	}
+	if rule.Protocol != "tcp" && rule.Protocol != "udp" {
+		return false
+	}
+	return rule.Ports == nil || *rule.Ports == "" || *rule.Ports == "1-65535" || *rule.Ports == "0-65535"
+}
+
+// quotaFinding
+//  1. no quota applied at all
+//  2. a quota with no total memory limit does not constrain anything
+func quotaFinding(guid string, quotas map[string]models.Quota) string {
+	// 1.
+	quota, ok := quotas[guid]
+	if guid == "" || !ok {
+		return "missing"
+	}
+	// 2.
+	if quota.Apps.TotalMemory == nil || !quota.Apps.TotalMemory.IsSet {
+		return "unlimited"
+	}
+	return ""
+}
+
+// reportSecurityPostureMetrics
+//  1. orgs always have a quota, the default one unless set otherwise
+//  2. only service keys are credentials handed out of the platform,
+//     app bindings are rotated with the application
+func (c SecurityPostureCollector) reportSecurityPostureMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	c.openSecurityGroupRuleMetric.Reset()
+	c.sshEnabledSpaceMetric.Reset()
+	c.sshEnabledApplicationMetric.Reset()
+	c.orgWithoutQuotaMetric.Reset()
+	c.spaceWithoutQuotaMetric.Reset()
+	c.unsupportedStackMetric.Reset()
+	c.staleServiceKeyMetric.Reset()
+
+	for _, group := range objs.SecurityGroups {
+		for _, rule := range group.Rules {
+			if !isOpenRule(rule) {
+				continue
+			}
+			ports := ""
+			if rule.Ports != nil {
+				ports = *rule.Ports
+			}
+			c.openSecurityGroupRuleMetric.WithLabelValues(
+				group.GUID,
+				group.Name,
+				rule.Protocol,
+				rule.Destination,
+				ports,
+			).Set(float64(1))
+		}
+	}
+
+	// 1.
+	for _, org := range objs.Orgs {
+		if reason := quotaFinding(org.QuotaGUID, objs.OrgQuotas); reason != "" {
+			c.orgWithoutQuotaMetric.WithLabelValues(
+				org.GUID,
+				org.Name,
+				reason,
+			).Set(float64(1))
+		}
+	}
+
+	for _, space := range objs.Spaces {
+		orgGUID := space.Relationships[constant.RelationshipTypeOrganization].GUID
+		orgName := objs.Orgs[orgGUID].Name
+
+		if objs.SSHSpaces[space.GUID] {
+			c.sshEnabledSpaceMetric.WithLabelValues(
+				space.GUID,
+				space.Name,
+				orgGUID,
+				orgName,
+			).Set(float64(1))
+		}
+
+		quotaGUID := space.Relationships[constant.RelationshipTypeQuota].GUID
+		if reason := quotaFinding(quotaGUID, objs.SpaceQuotas); reason != "" {
+			c.spaceWithoutQuotaMetric.WithLabelValues(
+				space.GUID,
+				space.Name,
+				orgGUID,
+				orgName,
+				reason,
+			).Set(float64(1))
+		}
+	}
+
+	for _, app := range objs.Apps {
+		spaceGUID := app.Relationships[constant.RelationshipTypeSpace].GUID
+		space := objs.Spaces[spaceGUID]
+		orgGUID := space.Relationships[constant.RelationshipTypeOrganization].GUID
+
+		if objs.SSHApps[app.GUID] {
+			c.sshEnabledApplicationMetric.WithLabelValues(
+				app.GUID,
+				app.Name,
+				spaceGUID,
+				space.Name,
+				orgGUID,
+				objs.Orgs[orgGUID].Name,
+			).Set(float64(1))
+		}
+
+		if app.Lifecycle.Type == constant.AppLifecycleTypeDocker {
+			continue
+		}
+		stack, ok := objs.StacksByName[app.Lifecycle.Data.Stack]
+		reason := ""
+		if !ok {
+			reason = "unknown"
+		} else if IsDeprecatedStack(stack, c.deprecatedStacks) {
+			reason = "deprecated"
+		}
+		if reason != "" {
+			c.unsupportedStackMetric.WithLabelValues(
+				app.GUID,
+				app.Name,
+				spaceGUID,
+				app.Lifecycle.Data.Stack,
+				reason,
+			).Set(float64(1))
+		}
+	}
+
+	// 2.
+	for _, binding := range objs.ServiceBindings {
+		if binding.Type != resources.KeyBinding {
+			continue
+		}
+		createdAt, err := time.Parse(time.RFC3339, binding.CreatedAt)
+		if err != nil || time.Since(createdAt) <= c.serviceKeyMaxAge {
+			continue
+		}
+		c.staleServiceKeyMetric.WithLabelValues(
+			binding.GUID,
+			binding.Name,
+			binding.ServiceInstanceGUID,
+		).Set(float64(1))
+	}
+
+	c.openSecurityGroupRuleMetric.Collect(ch)
+	c.sshEnabledSpaceMetric.Collect(ch)
+	c.sshEnabledApplicationMetric.Collect(ch)
+	c.orgWithoutQuotaMetric.Collect(ch)
+	c.spaceWithoutQuotaMetric.Collect(ch)
+	c.unsupportedStackMetric.Collect(ch)
+	c.staleServiceKeyMetric.Collect(ch)
+}
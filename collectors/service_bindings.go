@@ -12,6 +12,9 @@ type ServiceBindingsCollector struct {
 	environment                                    string
 	deployment                                     string
 	serviceBindingInfoMetric                       *prometheus.GaugeVec
+	serviceBindingCreatedAtMetric                  *prometheus.GaugeVec
+	serviceBindingUpdatedAtMetric                  *prometheus.GaugeVec
+	serviceInstanceBindingsMetric                  *prometheus.GaugeVec
 	serviceBindingsScrapesTotalMetric              prometheus.Counter
 	serviceBindingsScrapeErrorsTotalMetric         prometheus.Counter
 	lastServiceBindingsScrapeErrorMetric           prometheus.Gauge
@@ -32,7 +35,40 @@ func NewServiceBindingsCollector(
 			Help:        "Labeled Cloud Foundry Service Binding information with a constant '1' value.",
 			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
 		},
-		[]string{"service_binding_id", "application_id", "service_instance_id"},
+		[]string{"service_binding_id", "service_binding_name", "type", "application_id", "service_instance_id", "last_operation_type", "last_operation_state"},
+	)
+
+	serviceBindingCreatedAtMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_binding",
+			Name:        "created_at",
+			Help:        "Number of seconds since 1970 since a Cloud Foundry Service Binding was created.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"service_binding_id", "service_binding_name", "type", "service_instance_id"},
+	)
+
+	serviceBindingUpdatedAtMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_binding",
+			Name:        "updated_at",
+			Help:        "Number of seconds since 1970 since a Cloud Foundry Service Binding was last updated.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"service_binding_id", "service_binding_name", "type", "service_instance_id"},
+	)
+
+	serviceInstanceBindingsMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_instance",
+			Name:        "bindings",
+			Help:        "Number of Service Bindings of a Cloud Foundry Service Instance by type (app bindings or service keys).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"service_instance_id", "type"},
 	)
 
 	serviceBindingsScrapesTotalMetric := prometheus.NewCounter(
@@ -90,6 +126,9 @@ func NewServiceBindingsCollector(
 		environment:                                    environment,
 		deployment:                                     deployment,
 		serviceBindingInfoMetric:                       serviceBindingInfoMetric,
+		serviceBindingCreatedAtMetric:                  serviceBindingCreatedAtMetric,
+		serviceBindingUpdatedAtMetric:                  serviceBindingUpdatedAtMetric,
+		serviceInstanceBindingsMetric:                  serviceInstanceBindingsMetric,
 		serviceBindingsScrapesTotalMetric:              serviceBindingsScrapesTotalMetric,
 		serviceBindingsScrapeErrorsTotalMetric:         serviceBindingsScrapeErrorsTotalMetric,
 		lastServiceBindingsScrapeErrorMetric:           lastServiceBindingsScrapeErrorMetric,
@@ -119,6 +158,9 @@ func (c ServiceBindingsCollector) Collect(objs *models.CFObjects, ch chan<- prom
 
 func (c ServiceBindingsCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.serviceBindingInfoMetric.Describe(ch)
+	c.serviceBindingCreatedAtMetric.Describe(ch)
+	c.serviceBindingUpdatedAtMetric.Describe(ch)
+	c.serviceInstanceBindingsMetric.Describe(ch)
 	c.serviceBindingsScrapesTotalMetric.Describe(ch)
 	c.serviceBindingsScrapeErrorsTotalMetric.Describe(ch)
 	c.lastServiceBindingsScrapeErrorMetric.Describe(ch)
@@ -128,13 +170,57 @@ func (c ServiceBindingsCollector) Describe(ch chan<- *prometheus.Desc) {
 
 func (c ServiceBindingsCollector) reportServiceBindingsMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
 	c.serviceBindingInfoMetric.Reset()
+	c.serviceBindingCreatedAtMetric.Reset()
+	c.serviceBindingUpdatedAtMetric.Reset()
+	c.serviceInstanceBindingsMetric.Reset()
+
+	type keyType struct {
+		serviceInstanceID string
+		bindingType       string
+	}
+	counts := map[keyType]int{}
 
 	for _, cItem := range objs.ServiceBindings {
 		c.serviceBindingInfoMetric.WithLabelValues(
 			cItem.GUID,
+			cItem.Name,
+			string(cItem.Type),
 			cItem.AppGUID,
 			cItem.ServiceInstanceGUID,
+			string(cItem.LastOperation.Type),
+			string(cItem.LastOperation.State),
 		).Set(float64(1))
+
+		if createdAt, err := time.Parse(time.RFC3339, cItem.CreatedAt); err == nil {
+			c.serviceBindingCreatedAtMetric.WithLabelValues(
+				cItem.GUID,
+				cItem.Name,
+				string(cItem.Type),
+				cItem.ServiceInstanceGUID,
+			).Set(float64(createdAt.Unix()))
+		}
+
+		if updatedAt, err := time.Parse(time.RFC3339, cItem.UpdatedAt); err == nil {
+			c.serviceBindingUpdatedAtMetric.WithLabelValues(
+				cItem.GUID,
+				cItem.Name,
+				string(cItem.Type),
+				cItem.ServiceInstanceGUID,
+			).Set(float64(updatedAt.Unix()))
+		}
+
+		counts[keyType{cItem.ServiceInstanceGUID, string(cItem.Type)}]++
 	}
+
+	for key, count := range counts {
+		c.serviceInstanceBindingsMetric.WithLabelValues(
+			key.serviceInstanceID,
+			key.bindingType,
+		).Set(float64(count))
+	}
+
 	c.serviceBindingInfoMetric.Collect(ch)
+	c.serviceBindingCreatedAtMetric.Collect(ch)
+	c.serviceBindingUpdatedAtMetric.Collect(ch)
+	c.serviceInstanceBindingsMetric.Collect(ch)
 }
@@ -0,0 +1,243 @@
+package collectors
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cloudfoundry/cf_exporter/v2/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type ServiceBrokersCollector struct {
+	namespace                                     string
+	environment                                   string
+	deployment                                    string
+	serviceBrokerInfoMetric                       *prometheus.GaugeVec
+	serviceBrokerServiceOfferingsMetric           *prometheus.GaugeVec
+	serviceBrokerServicePlansMetric               *prometheus.GaugeVec
+	serviceBrokerServiceInstancesMetric           *prometheus.GaugeVec
+	serviceBrokersScrapesTotalMetric              prometheus.Counter
+	serviceBrokersScrapeErrorsTotalMetric         prometheus.Counter
+	lastServiceBrokersScrapeErrorMetric           prometheus.Gauge
+	lastServiceBrokersScrapeTimestampMetric       prometheus.Gauge
+	lastServiceBrokersScrapeDurationSecondsMetric prometheus.Gauge
+}
+
+func NewServiceBrokersCollector(
+	namespace string,
+	environment string,
+	deployment string,
+) *ServiceBrokersCollector {
+	serviceBrokerInfoMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_broker",
+			Name:        "info",
+			Help:        "Labeled Cloud Foundry Service Broker information with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"service_broker_id", "service_broker_name", "service_broker_host", "space_id", "space_scoped"},
+	)
+
+	serviceBrokerServiceOfferingsMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_broker",
+			Name:        "service_offerings",
+			Help:        "Number of Service Offerings of a Cloud Foundry Service Broker.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"service_broker_id", "service_broker_name"},
+	)
+
+	serviceBrokerServicePlansMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_broker",
+			Name:        "service_plans",
+			Help:        "Number of Service Plans of a Cloud Foundry Service Broker.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"service_broker_id", "service_broker_name"},
+	)
+
+	serviceBrokerServiceInstancesMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_broker",
+			Name:        "service_instances",
+			Help:        "Number of Service Instances provisioned by a Cloud Foundry Service Broker.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"service_broker_id", "service_broker_name"},
+	)
+
+	serviceBrokersScrapesTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_brokers_scrapes",
+			Name:        "total",
+			Help:        "Total number of scrapes for Cloud Foundry Service Brokers.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	serviceBrokersScrapeErrorsTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_brokers_scrape_errors",
+			Name:        "total",
+			Help:        "Total number of scrape error of Cloud Foundry Service Brokers.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastServiceBrokersScrapeErrorMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_service_brokers_scrape_error",
+			Help:        "Whether the last scrape of Service Brokers metrics from Cloud Foundry resulted in an error (1 for error, 0 for success).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastServiceBrokersScrapeTimestampMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_service_brokers_scrape_timestamp",
+			Help:        "Number of seconds since 1970 since last scrape of Service Brokers metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastServiceBrokersScrapeDurationSecondsMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_service_brokers_scrape_duration_seconds",
+			Help:        "Duration of the last scrape of Service Brokers metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	return &ServiceBrokersCollector{
+		namespace:                                     namespace,
+		environment:                                   environment,
+		deployment:                                    deployment,
+		serviceBrokerInfoMetric:                       serviceBrokerInfoMetric,
+		serviceBrokerServiceOfferingsMetric:           serviceBrokerServiceOfferingsMetric,
+		serviceBrokerServicePlansMetric:               serviceBrokerServicePlansMetric,
+		serviceBrokerServiceInstancesMetric:           serviceBrokerServiceInstancesMetric,
+		serviceBrokersScrapesTotalMetric:              serviceBrokersScrapesTotalMetric,
+		serviceBrokersScrapeErrorsTotalMetric:         serviceBrokersScrapeErrorsTotalMetric,
+		lastServiceBrokersScrapeErrorMetric:           lastServiceBrokersScrapeErrorMetric,
+		lastServiceBrokersScrapeTimestampMetric:       lastServiceBrokersScrapeTimestampMetric,
+		lastServiceBrokersScrapeDurationSecondsMetric: lastServiceBrokersScrapeDurationSecondsMetric,
+	}
+}
+
+func (c ServiceBrokersCollector) Collect(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	errorMetric := float64(0)
+	if objs.Error != nil {
+		errorMetric = float64(1)
+		c.serviceBrokersScrapeErrorsTotalMetric.Inc()
+	} else {
+		c.reportServiceBrokersMetrics(objs, ch)
+	}
+
+	c.serviceBrokersScrapeErrorsTotalMetric.Collect(ch)
+	c.serviceBrokersScrapesTotalMetric.Inc()
+	c.serviceBrokersScrapesTotalMetric.Collect(ch)
+	c.lastServiceBrokersScrapeErrorMetric.Set(errorMetric)
+	c.lastServiceBrokersScrapeErrorMetric.Collect(ch)
+	c.lastServiceBrokersScrapeTimestampMetric.Set(float64(time.Now().Unix()))
+	c.lastServiceBrokersScrapeTimestampMetric.Collect(ch)
+	c.lastServiceBrokersScrapeDurationSecondsMetric.Set(objs.Took)
+	c.lastServiceBrokersScrapeDurationSecondsMetric.Collect(ch)
+}
+
+func (c ServiceBrokersCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.serviceBrokerInfoMetric.Describe(ch)
+	c.serviceBrokerServiceOfferingsMetric.Describe(ch)
+	c.serviceBrokerServicePlansMetric.Describe(ch)
+	c.serviceBrokerServiceInstancesMetric.Describe(ch)
+	c.serviceBrokersScrapesTotalMetric.Describe(ch)
+	c.serviceBrokersScrapeErrorsTotalMetric.Describe(ch)
+	c.lastServiceBrokersScrapeErrorMetric.Describe(ch)
+	c.lastServiceBrokersScrapeTimestampMetric.Describe(ch)
+	c.lastServiceBrokersScrapeDurationSecondsMetric.Describe(ch)
+}
+
+// reportServiceBrokersMetrics
+//  1. only keep the host of the broker url, credentials or paths are not
+//     relevant
+//  2. link instances to brokers through their plan and offering
+func (c ServiceBrokersCollector) reportServiceBrokersMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	c.serviceBrokerInfoMetric.Reset()
+	c.serviceBrokerServiceOfferingsMetric.Reset()
+	c.serviceBrokerServicePlansMetric.Reset()
+	c.serviceBrokerServiceInstancesMetric.Reset()
+
+	offerings := map[string]int{}
+	for _, offering := range objs.ServiceOfferings {
+		offerings[offering.ServiceBrokerGUID]++
+	}
+
+	plans := map[string]int{}
+	for _, plan := range objs.ServicePlans {
+		if offering, ok := objs.ServiceOfferings[plan.ServiceOfferingGUID]; ok {
+			plans[offering.ServiceBrokerGUID]++
+		}
+	}
+
+	// 2.
+	instances := map[string]int{}
+	for _, instance := range objs.ServiceInstances {
+		plan, ok := objs.ServicePlans[instance.ServicePlanGUID]
+		if !ok {
+			continue
+		}
+		if offering, ok := objs.ServiceOfferings[plan.ServiceOfferingGUID]; ok {
+			instances[offering.ServiceBrokerGUID]++
+		}
+	}
+
+	for _, broker := range objs.ServiceBrokers {
+		// 1.
+		host := ""
+		if u, err := url.Parse(broker.URL); err == nil {
+			host = u.Hostname()
+		}
+
+		c.serviceBrokerInfoMetric.WithLabelValues(
+			broker.GUID,
+			broker.Name,
+			host,
+			broker.SpaceGUID,
+			strconv.FormatBool(broker.SpaceGUID != ""),
+		).Set(float64(1))
+
+		c.serviceBrokerServiceOfferingsMetric.WithLabelValues(
+			broker.GUID,
+			broker.Name,
+		).Set(float64(offerings[broker.GUID]))
+
+		c.serviceBrokerServicePlansMetric.WithLabelValues(
+			broker.GUID,
+			broker.Name,
+		).Set(float64(plans[broker.GUID]))
+
+		c.serviceBrokerServiceInstancesMetric.WithLabelValues(
+			broker.GUID,
+			broker.Name,
+		).Set(float64(instances[broker.GUID]))
+	}
+
+	c.serviceBrokerInfoMetric.Collect(ch)
+	c.serviceBrokerServiceOfferingsMetric.Collect(ch)
+	c.serviceBrokerServicePlansMetric.Collect(ch)
+	c.serviceBrokerServiceInstancesMetric.Collect(ch)
+}
@@ -1,8 +1,10 @@
 package collectors
 
 import (
+	"strconv"
 	"time"
 
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
 	"code.cloudfoundry.org/cli/v8/resources"
 	"github.com/cloudfoundry/cf_exporter/v2/models"
 	"github.com/prometheus/client_golang/prometheus"
@@ -13,17 +15,24 @@ type ServiceInstancesCollector struct {
 	environment                                     string
 	deployment                                      string
 	serviceInstanceInfoMetric                       *prometheus.GaugeVec
+	serviceInstanceLastUpdateMetric                 *prometheus.GaugeVec
+	serviceInstanceStuckMetric                      *prometheus.GaugeVec
+	serviceInstanceUpgradeMetric                    *prometheus.GaugeVec
+	serviceInstanceSharedSpaceMetric                *prometheus.GaugeVec
+	serviceInstanceSharesMetric                     *prometheus.GaugeVec
 	serviceInstancesScrapesTotalMetric              prometheus.Counter
 	serviceInstancesScrapeErrorsTotalMetric         prometheus.Counter
 	lastServiceInstancesScrapeErrorMetric           prometheus.Gauge
 	lastServiceInstancesScrapeTimestampMetric       prometheus.Gauge
 	lastServiceInstancesScrapeDurationSecondsMetric prometheus.Gauge
+	stuckThreshold                                  time.Duration
 }
 
 func NewServiceInstancesCollector(
 	namespace string,
 	environment string,
 	deployment string,
+	stuckThreshold time.Duration,
 ) *ServiceInstancesCollector {
 	serviceInstanceInfoMetric := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -36,6 +45,61 @@ func NewServiceInstancesCollector(
 		[]string{"service_instance_id", "service_instance_name", "service_plan_id", "space_id", "type", "last_operation_type", "last_operation_state"},
 	)
 
+	serviceInstanceLastUpdateMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_instance",
+			Name:        "last_operation_updated_at",
+			Help:        "Number of seconds since 1970 since a Cloud Foundry Service Instance last operation was updated.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"service_instance_id", "service_instance_name", "last_operation_type", "last_operation_state"},
+	)
+
+	serviceInstanceStuckMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_instance",
+			Name:        "last_operation_stuck",
+			Help:        "Whether a Cloud Foundry Service Instance last operation is in progress for longer than the stuck threshold since it started (1 for stuck, 0 otherwise).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"service_instance_id", "service_instance_name", "last_operation_type"},
+	)
+
+	serviceInstanceUpgradeMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_instance",
+			Name:        "upgrade_available",
+			Help:        "Whether a Cloud Foundry Service Instance maintenance info version is behind its Service Plan version (1 for upgrade available, 0 otherwise).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"service_instance_id", "service_instance_name", "service_plan_id", "maintenance_info_version", "service_plan_maintenance_info_version"},
+	)
+
+	serviceInstanceSharedSpaceMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_instance",
+			Name:        "shared_space",
+			Help:        "Labeled Cloud Foundry Service Instance sharing to a target space with a constant '1' value.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"service_instance_id", "service_instance_name", "source_space_id", "source_organization_id", "target_space_id", "target_space_name", "target_organization_id", "target_organization_name", "cross_organization"},
+	)
+
+	serviceInstanceSharesMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_instance",
+			Name:        "shares",
+			Help:        "Number of spaces a Cloud Foundry Service Instance is shared to.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"service_instance_id", "service_instance_name", "space_id"},
+	)
+
 	serviceInstancesScrapesTotalMetric := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace:   namespace,
@@ -91,11 +155,17 @@ func NewServiceInstancesCollector(
 		environment:                                     environment,
 		deployment:                                      deployment,
 		serviceInstanceInfoMetric:                       serviceInstanceInfoMetric,
+		serviceInstanceLastUpdateMetric:                 serviceInstanceLastUpdateMetric,
+		serviceInstanceStuckMetric:                      serviceInstanceStuckMetric,
+		serviceInstanceUpgradeMetric:                    serviceInstanceUpgradeMetric,
+		serviceInstanceSharedSpaceMetric:                serviceInstanceSharedSpaceMetric,
+		serviceInstanceSharesMetric:                     serviceInstanceSharesMetric,
 		serviceInstancesScrapesTotalMetric:              serviceInstancesScrapesTotalMetric,
 		serviceInstancesScrapeErrorsTotalMetric:         serviceInstancesScrapeErrorsTotalMetric,
 		lastServiceInstancesScrapeErrorMetric:           lastServiceInstancesScrapeErrorMetric,
 		lastServiceInstancesScrapeTimestampMetric:       lastServiceInstancesScrapeTimestampMetric,
 		lastServiceInstancesScrapeDurationSecondsMetric: lastServiceInstancesScrapeDurationSecondsMetric,
+		stuckThreshold:                                  stuckThreshold,
 	}
 }
 
@@ -121,6 +191,11 @@ func (c ServiceInstancesCollector) Collect(objs *models.CFObjects, ch chan<- pro
 
 func (c ServiceInstancesCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.serviceInstanceInfoMetric.Describe(ch)
+	c.serviceInstanceLastUpdateMetric.Describe(ch)
+	c.serviceInstanceStuckMetric.Describe(ch)
+	c.serviceInstanceUpgradeMetric.Describe(ch)
+	c.serviceInstanceSharedSpaceMetric.Describe(ch)
+	c.serviceInstanceSharesMetric.Describe(ch)
 	c.serviceInstancesScrapesTotalMetric.Describe(ch)
 	c.serviceInstancesScrapeErrorsTotalMetric.Describe(ch)
 	c.lastServiceInstancesScrapeErrorMetric.Describe(ch)
@@ -129,9 +204,20 @@ func (c ServiceInstancesCollector) Describe(ch chan<- *prometheus.Desc) {
 }
 
 // reportServiceInstancesMetrics
-// 1. v0 compatibility
+//  1. v0 compatibility
+//  2. user provided instances have no last operation, an operation is stuck
+//     from its start as the broker polling keeps refreshing its update time
+//  3. trust the cloud controller when it computed the upgrade availability,
+//     compare with the plan maintenance info version otherwise
+//  4. resolve organizations through the spaces, the shared spaces only carry
+//     the organization name
 func (c ServiceInstancesCollector) reportServiceInstancesMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
 	c.serviceInstanceInfoMetric.Reset()
+	c.serviceInstanceLastUpdateMetric.Reset()
+	c.serviceInstanceStuckMetric.Reset()
+	c.serviceInstanceUpgradeMetric.Reset()
+	c.serviceInstanceSharedSpaceMetric.Reset()
+	c.serviceInstanceSharesMetric.Reset()
 
 	for _, cElem := range objs.ServiceInstances {
 		// 1.
@@ -149,7 +235,83 @@ func (c ServiceInstancesCollector) reportServiceInstancesMetrics(objs *models.CF
 			string(cElem.LastOperation.Type),
 			string(cElem.LastOperation.State),
 		).Set(float64(1))
+
+		// 2.
+		if updatedAt, err := time.Parse(time.RFC3339, cElem.LastOperation.UpdatedAt); err == nil {
+			c.serviceInstanceLastUpdateMetric.WithLabelValues(
+				cElem.GUID,
+				cElem.Name,
+				string(cElem.LastOperation.Type),
+				string(cElem.LastOperation.State),
+			).Set(float64(updatedAt.Unix()))
+		}
+		if createdAt, err := time.Parse(time.RFC3339, cElem.LastOperation.CreatedAt); err == nil {
+			stuck := float64(0)
+			if cElem.LastOperation.State == resources.OperationInProgress && time.Since(createdAt) > c.stuckThreshold {
+				stuck = float64(1)
+			}
+			c.serviceInstanceStuckMetric.WithLabelValues(
+				cElem.GUID,
+				cElem.Name,
+				string(cElem.LastOperation.Type),
+			).Set(stuck)
+		}
+
+		// 3.
+		if cElem.Type != resources.ManagedServiceInstance {
+			continue
+		}
+		planVersion := ""
+		if plan, ok := objs.ServicePlans[cElem.ServicePlanGUID]; ok {
+			planVersion = plan.MaintenanceInfoVersion
+		}
+		upgradeAvailable := CompareVersions(cElem.MaintenanceInfoVersion, planVersion) < 0
+		if cElem.UpgradeAvailable.IsSet {
+			upgradeAvailable = cElem.UpgradeAvailable.Value
+		}
+		c.serviceInstanceUpgradeMetric.WithLabelValues(
+			cElem.GUID,
+			cElem.Name,
+			cElem.ServicePlanGUID,
+			cElem.MaintenanceInfoVersion,
+			planVersion,
+		).Set(BoolToFloat(&upgradeAvailable))
+
+		// 4.
+		sourceOrgGUID := ""
+		if space, ok := objs.Spaces[cElem.SpaceGUID]; ok {
+			sourceOrgGUID = space.Relationships[constant.RelationshipTypeOrganization].GUID
+		}
+		shares := objs.SharedSpaces[cElem.GUID]
+		for _, share := range shares {
+			targetOrgGUID := ""
+			if space, ok := objs.Spaces[share.SpaceGUID]; ok {
+				targetOrgGUID = space.Relationships[constant.RelationshipTypeOrganization].GUID
+			}
+			crossOrg := targetOrgGUID != sourceOrgGUID
+			c.serviceInstanceSharedSpaceMetric.WithLabelValues(
+				cElem.GUID,
+				cElem.Name,
+				cElem.SpaceGUID,
+				sourceOrgGUID,
+				share.SpaceGUID,
+				share.SpaceName,
+				targetOrgGUID,
+				share.OrganizationName,
+				strconv.FormatBool(crossOrg),
+			).Set(float64(1))
+		}
+		c.serviceInstanceSharesMetric.WithLabelValues(
+			cElem.GUID,
+			cElem.Name,
+			cElem.SpaceGUID,
+		).Set(float64(len(shares)))
 	}
 
 	c.serviceInstanceInfoMetric.Collect(ch)
+	c.serviceInstanceLastUpdateMetric.Collect(ch)
+	c.serviceInstanceStuckMetric.Collect(ch)
+	c.serviceInstanceUpgradeMetric.Collect(ch)
+	c.serviceInstanceSharedSpaceMetric.Collect(ch)
+	c.serviceInstanceSharesMetric.Collect(ch)
 }
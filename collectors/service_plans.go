@@ -1,6 +1,7 @@
 package collectors
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/cloudfoundry/cf_exporter/v2/models"
@@ -12,6 +13,8 @@ type ServicePlansCollector struct {
 	environment                                 string
 	deployment                                  string
 	servicePlanInfoMetric                       *prometheus.GaugeVec
+	servicePlanCostMetric                       *prometheus.GaugeVec
+	servicePlanServiceInstancesMetric           *prometheus.GaugeVec
 	servicePlansScrapesTotalMetric              prometheus.Counter
 	servicePlansScrapeErrorsTotalMetric         prometheus.Counter
 	lastServicePlansScrapeErrorMetric           prometheus.Gauge
@@ -32,6 +35,28 @@ func NewServicePlansCollector(
 			Help:        "Labeled Cloud Foundry Service Plan information with a constant '1' value.",
 			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
 		},
+		[]string{"service_plan_id", "service_plan_name", "service_id", "free", "available", "visibility_type", "maintenance_info_version"},
+	)
+
+	servicePlanCostMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_plan",
+			Name:        "cost",
+			Help:        "Cost amount of a Cloud Foundry Service Plan.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"service_plan_id", "service_plan_name", "service_id", "currency", "unit"},
+	)
+
+	servicePlanServiceInstancesMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "service_plan",
+			Name:        "service_instances",
+			Help:        "Number of Service Instances of a Cloud Foundry Service Plan.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
 		[]string{"service_plan_id", "service_plan_name", "service_id"},
 	)
 
@@ -90,6 +115,8 @@ func NewServicePlansCollector(
 		environment:                                 environment,
 		deployment:                                  deployment,
 		servicePlanInfoMetric:                       servicePlanInfoMetric,
+		servicePlanCostMetric:                       servicePlanCostMetric,
+		servicePlanServiceInstancesMetric:           servicePlanServiceInstancesMetric,
 		servicePlansScrapesTotalMetric:              servicePlansScrapesTotalMetric,
 		servicePlansScrapeErrorsTotalMetric:         servicePlansScrapeErrorsTotalMetric,
 		lastServicePlansScrapeErrorMetric:           lastServicePlansScrapeErrorMetric,
@@ -120,6 +147,8 @@ func (c ServicePlansCollector) Collect(objs *models.CFObjects, ch chan<- prometh
 
 func (c ServicePlansCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.servicePlanInfoMetric.Describe(ch)
+	c.servicePlanCostMetric.Describe(ch)
+	c.servicePlanServiceInstancesMetric.Describe(ch)
 	c.servicePlansScrapesTotalMetric.Describe(ch)
 	c.servicePlansScrapeErrorsTotalMetric.Describe(ch)
 	c.lastServicePlansScrapeErrorMetric.Describe(ch)
@@ -129,14 +158,43 @@ func (c ServicePlansCollector) Describe(ch chan<- *prometheus.Desc) {
 
 func (c ServicePlansCollector) reportServicePlansMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
 	c.servicePlanInfoMetric.Reset()
+	c.servicePlanCostMetric.Reset()
+	c.servicePlanServiceInstancesMetric.Reset()
+
+	instances := map[string]int{}
+	for _, instance := range objs.ServiceInstances {
+		instances[instance.ServicePlanGUID]++
+	}
 
 	for _, cElem := range objs.ServicePlans {
 		c.servicePlanInfoMetric.WithLabelValues(
 			cElem.GUID,
 			cElem.Name,
 			cElem.ServiceOfferingGUID,
+			strconv.FormatBool(cElem.Free),
+			strconv.FormatBool(cElem.Available),
+			string(cElem.VisibilityType),
+			cElem.MaintenanceInfoVersion,
 		).Set(float64(1))
+
+		for _, cost := range cElem.Costs {
+			c.servicePlanCostMetric.WithLabelValues(
+				cElem.GUID,
+				cElem.Name,
+				cElem.ServiceOfferingGUID,
+				cost.Currency,
+				cost.Unit,
+			).Set(cost.Amount)
+		}
+
+		c.servicePlanServiceInstancesMetric.WithLabelValues(
+			cElem.GUID,
+			cElem.Name,
+			cElem.ServiceOfferingGUID,
+		).Set(float64(instances[cElem.GUID]))
 	}
 
 	c.servicePlanInfoMetric.Collect(ch)
+	c.servicePlanCostMetric.Collect(ch)
+	c.servicePlanServiceInstancesMetric.Collect(ch)
 }
@@ -1,6 +1,8 @@
 package collectors
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cloudfoundry/cf_exporter/v2/models"
@@ -32,7 +34,7 @@ func NewServicesCollector(
 			Help:        "Labeled Cloud Foundry Service information with a constant '1' value.",
 			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
 		},
-		[]string{"service_id", "service_label"},
+		[]string{"service_id", "service_label", "service_broker_id", "service_broker_name", "available", "bindable", "shareable", "tags"},
 	)
 
 	servicesScrapesTotalMetric := prometheus.NewCounter(
@@ -131,9 +133,20 @@ func (c ServicesCollector) reportServicesMetrics(objs *models.CFObjects, ch chan
 	c.serviceInfoMetric.Reset()
 
 	for _, cService := range objs.ServiceOfferings {
+		brokerName := ""
+		if broker, ok := objs.ServiceBrokers[cService.ServiceBrokerGUID]; ok {
+			brokerName = broker.Name
+		}
+
 		c.serviceInfoMetric.WithLabelValues(
 			cService.GUID,
 			cService.Name,
+			cService.ServiceBrokerGUID,
+			brokerName,
+			strconv.FormatBool(cService.Available),
+			strconv.FormatBool(cService.BrokerCatalog.Features.Bindable),
+			strconv.FormatBool(cService.AllowsInstanceSharing),
+			strings.Join(cService.Tags.Value, ","),
 		).Set(float64(1))
 	}
 
@@ -21,6 +21,7 @@ type SpacesCollector struct {
 	spaceTotalAppInstancesQuotaMetric       *prometheus.GaugeVec
 	spaceTotalAppTasksQuotaMetric           *prometheus.GaugeVec
 	spaceTotalMemoryMbQuotaMetric           *prometheus.GaugeVec
+	spaceTotalLogRateLimitQuotaMetric       *prometheus.GaugeVec
 	spaceTotalReservedRoutePortsQuotaMetric *prometheus.GaugeVec
 	spaceTotalRoutesQuotaMetric             *prometheus.GaugeVec
 	spaceTotalServiceKeysQuotaMetric        *prometheus.GaugeVec
@@ -103,6 +104,17 @@ func NewSpacesCollector(
 		[]string{"space_id", "space_name", "organization_id"},
 	)
 
+	spaceTotalLogRateLimitQuotaMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "space",
+			Name:        "total_log_rate_limit_bytes_per_second_quota",
+			Help:        "Total log rate limit (bytes per second) a Cloud Foundry Space can have.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"space_id", "space_name", "organization_id"},
+	)
+
 	spaceTotalReservedRoutePortsQuotaMetric := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace:   namespace,
@@ -207,6 +219,7 @@ func NewSpacesCollector(
 		spaceTotalAppInstancesQuotaMetric:       spaceTotalAppInstancesQuotaMetric,
 		spaceTotalAppTasksQuotaMetric:           spaceTotalAppTasksQuotaMetric,
 		spaceTotalMemoryMbQuotaMetric:           spaceTotalMemoryMbQuotaMetric,
+		spaceTotalLogRateLimitQuotaMetric:       spaceTotalLogRateLimitQuotaMetric,
 		spaceTotalReservedRoutePortsQuotaMetric: spaceTotalReservedRoutePortsQuotaMetric,
 		spaceTotalRoutesQuotaMetric:             spaceTotalRoutesQuotaMetric,
 		spaceTotalServiceKeysQuotaMetric:        spaceTotalServiceKeysQuotaMetric,
@@ -250,6 +263,7 @@ func (c SpacesCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.spaceTotalAppInstancesQuotaMetric.Describe(ch)
 	c.spaceTotalAppTasksQuotaMetric.Describe(ch)
 	c.spaceTotalMemoryMbQuotaMetric.Describe(ch)
+	c.spaceTotalLogRateLimitQuotaMetric.Describe(ch)
 	c.spaceTotalReservedRoutePortsQuotaMetric.Describe(ch)
 	c.spaceTotalRoutesQuotaMetric.Describe(ch)
 	c.spaceTotalServiceKeysQuotaMetric.Describe(ch)
@@ -308,6 +322,12 @@ func (c SpacesCollector) reportSpace(space resources.Space, objs *models.CFObjec
 			relOrg.GUID,
 		).Set(NullIntToFloat(quota.Apps.TotalMemory))
 
+		c.spaceTotalLogRateLimitQuotaMetric.WithLabelValues(
+			space.GUID,
+			space.Name,
+			relOrg.GUID,
+		).Set(NullIntToFloat(quota.Apps.LogRateLimit))
+
 		c.spaceTotalReservedRoutePortsQuotaMetric.WithLabelValues(
 			space.GUID,
 			space.Name,
@@ -353,6 +373,7 @@ func (c SpacesCollector) reportSpacesMetrics(objs *models.CFObjects, ch chan<- p
 	c.spaceTotalAppInstancesQuotaMetric.Reset()
 	c.spaceTotalAppTasksQuotaMetric.Reset()
 	c.spaceTotalMemoryMbQuotaMetric.Reset()
+	c.spaceTotalLogRateLimitQuotaMetric.Reset()
 	c.spaceTotalReservedRoutePortsQuotaMetric.Reset()
 	c.spaceTotalRoutesQuotaMetric.Reset()
 	c.spaceTotalServiceKeysQuotaMetric.Reset()
@@ -373,6 +394,7 @@ func (c SpacesCollector) reportSpacesMetrics(objs *models.CFObjects, ch chan<- p
 	c.spaceTotalAppInstancesQuotaMetric.Collect(ch)
 	c.spaceTotalAppTasksQuotaMetric.Collect(ch)
 	c.spaceTotalMemoryMbQuotaMetric.Collect(ch)
+	c.spaceTotalLogRateLimitQuotaMetric.Collect(ch)
 	c.spaceTotalReservedRoutePortsQuotaMetric.Collect(ch)
 	c.spaceTotalRoutesQuotaMetric.Collect(ch)
 	c.spaceTotalServiceKeysQuotaMetric.Collect(ch)
@@ -3,6 +3,7 @@ package collectors
 import (
 	"time"
 
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
 	"github.com/cloudfoundry/cf_exporter/v2/models"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -12,17 +13,24 @@ type StacksCollector struct {
 	environment                           string
 	deployment                            string
 	stackInfoMetric                       *prometheus.GaugeVec
+	stackDefaultMetric                    *prometheus.GaugeVec
+	stackDeprecatedMetric                 *prometheus.GaugeVec
+	stackApplicationsMetric               *prometheus.GaugeVec
+	stackInstancesMetric                  *prometheus.GaugeVec
+	stackMemoryMetric                     *prometheus.GaugeVec
 	stacksScrapesTotalMetric              prometheus.Counter
 	stacksScrapeErrorsTotalMetric         prometheus.Counter
 	lastStacksScrapeErrorMetric           prometheus.Gauge
 	lastStacksScrapeTimestampMetric       prometheus.Gauge
 	lastStacksScrapeDurationSecondsMetric prometheus.Gauge
+	deprecatedStacks                      []string
 }
 
 func NewStacksCollector(
 	namespace string,
 	environment string,
 	deployment string,
+	deprecatedStacks []string,
 ) *StacksCollector {
 	stackInfoMetric := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -35,6 +43,61 @@ func NewStacksCollector(
 		[]string{"stack_id", "stack_name"},
 	)
 
+	stackDefaultMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "stack",
+			Name:        "default",
+			Help:        "Whether a Cloud Foundry Stack is the default stack of the foundation (1 for default, 0 otherwise).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"stack_id", "stack_name"},
+	)
+
+	stackDeprecatedMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "stack",
+			Name:        "deprecated",
+			Help:        "Whether a Cloud Foundry Stack is deprecated (1 for deprecated, 0 otherwise).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"stack_id", "stack_name"},
+	)
+
+	stackApplicationsMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "stack",
+			Name:        "applications",
+			Help:        "Number of Cloud Foundry Applications using a Stack.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"stack_id", "stack_name"},
+	)
+
+	stackInstancesMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "stack",
+			Name:        "application_instances",
+			Help:        "Number of desired instances of the started Cloud Foundry Applications using a Stack.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"stack_id", "stack_name"},
+	)
+
+	stackMemoryMetric := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "stack",
+			Name:        "memory_mb",
+			Help:        "Total memory in MB of the started Cloud Foundry Applications instances using a Stack.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"stack_id", "stack_name"},
+	)
+
 	stacksScrapesTotalMetric := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace:   namespace,
@@ -90,11 +153,17 @@ func NewStacksCollector(
 		environment:                           environment,
 		deployment:                            deployment,
 		stackInfoMetric:                       stackInfoMetric,
+		stackDefaultMetric:                    stackDefaultMetric,
+		stackDeprecatedMetric:                 stackDeprecatedMetric,
+		stackApplicationsMetric:               stackApplicationsMetric,
+		stackInstancesMetric:                  stackInstancesMetric,
+		stackMemoryMetric:                     stackMemoryMetric,
 		stacksScrapesTotalMetric:              stacksScrapesTotalMetric,
 		stacksScrapeErrorsTotalMetric:         stacksScrapeErrorsTotalMetric,
 		lastStacksScrapeErrorMetric:           lastStacksScrapeErrorMetric,
 		lastStacksScrapeTimestampMetric:       lastStacksScrapeTimestampMetric,
 		lastStacksScrapeDurationSecondsMetric: lastStacksScrapeDurationSecondsMetric,
+		deprecatedStacks:                      deprecatedStacks,
 	}
 }
 
@@ -120,6 +189,11 @@ func (c StacksCollector) Collect(objs *models.CFObjects, ch chan<- prometheus.Me
 
 func (c StacksCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.stackInfoMetric.Describe(ch)
+	c.stackDefaultMetric.Describe(ch)
+	c.stackDeprecatedMetric.Describe(ch)
+	c.stackApplicationsMetric.Describe(ch)
+	c.stackInstancesMetric.Describe(ch)
+	c.stackMemoryMetric.Describe(ch)
 	c.stacksScrapesTotalMetric.Describe(ch)
 	c.stacksScrapeErrorsTotalMetric.Describe(ch)
 	c.lastStacksScrapeErrorMetric.Describe(ch)
@@ -127,15 +201,74 @@ func (c StacksCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.lastStacksScrapeDurationSecondsMetric.Describe(ch)
 }
 
+// reportStacksMetrics
+//  1. docker applications have no stack
+//  2. only started applications have instances consuming memory
 func (c StacksCollector) reportStacksMetrics(objs *models.CFObjects, ch chan<- prometheus.Metric) {
 	c.stackInfoMetric.Reset()
+	c.stackDefaultMetric.Reset()
+	c.stackDeprecatedMetric.Reset()
+	c.stackApplicationsMetric.Reset()
+	c.stackInstancesMetric.Reset()
+	c.stackMemoryMetric.Reset()
+
+	apps := map[string]int{}
+	instances := map[string]int{}
+	memory := map[string]uint64{}
+	for _, app := range objs.Apps {
+		// 1.
+		stack, ok := objs.StacksByName[app.Lifecycle.Data.Stack]
+		if !ok {
+			continue
+		}
+		apps[stack.GUID]++
+		// 2.
+		if app.State != constant.ApplicationStarted {
+			continue
+		}
+		for _, process := range objs.AppProcesses[app.GUID] {
+			instances[stack.GUID] += process.Instances.Value
+			memory[stack.GUID] += uint64(process.Instances.Value) * process.MemoryInMB.Value
+		}
+	}
 
 	for _, cStack := range objs.Stacks {
 		c.stackInfoMetric.WithLabelValues(
 			cStack.GUID,
 			cStack.Name,
 		).Set(float64(1))
+
+		c.stackDefaultMetric.WithLabelValues(
+			cStack.GUID,
+			cStack.Name,
+		).Set(BoolToFloat(&cStack.Default))
+
+		deprecated := IsDeprecatedStack(cStack, c.deprecatedStacks)
+		c.stackDeprecatedMetric.WithLabelValues(
+			cStack.GUID,
+			cStack.Name,
+		).Set(BoolToFloat(&deprecated))
+
+		c.stackApplicationsMetric.WithLabelValues(
+			cStack.GUID,
+			cStack.Name,
+		).Set(float64(apps[cStack.GUID]))
+
+		c.stackInstancesMetric.WithLabelValues(
+			cStack.GUID,
+			cStack.Name,
+		).Set(float64(instances[cStack.GUID]))
+
+		c.stackMemoryMetric.WithLabelValues(
+			cStack.GUID,
+			cStack.Name,
+		).Set(float64(memory[cStack.GUID]))
 	}
 
 	c.stackInfoMetric.Collect(ch)
+	c.stackDefaultMetric.Collect(ch)
+	c.stackDeprecatedMetric.Collect(ch)
+	c.stackApplicationsMetric.Collect(ch)
+	c.stackInstancesMetric.Collect(ch)
+	c.stackMemoryMetric.Collect(ch)
 }
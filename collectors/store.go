@@ -0,0 +1,58 @@
+package collectors
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// FileStore stores a collector state as a JSON file
+type FileStore[T any] struct {
+	path string
+}
+
+func NewFileStore[T any](path string) *FileStore[T] {
+	return &FileStore[T]{path: path}
+}
+
+func (s *FileStore[T]) Load() (*T, error) {
+	content, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := new(T)
+	if err := json.Unmarshal(content, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Save writes the state to a temporary file renamed over the previous one, so
+// that a crash while saving never leaves a truncated state behind
+func (s *FileStore[T]) Save(state *T) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	// the temporary file is already gone once renamed
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,71 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("FileStore", func() {
+	var (
+		dir   string
+		path  string
+		store *FileStore[EventsState]
+	)
+
+	ginkgo.BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "cf_exporter_store")
+		gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+		path = filepath.Join(dir, "events.json")
+		store = NewFileStore[EventsState](path)
+	})
+
+	ginkgo.AfterEach(func() {
+		gomega.Ω(os.RemoveAll(dir)).Should(gomega.Succeed())
+	})
+
+	ginkgo.It("loads the saved state", func() {
+		state := &EventsState{
+			LastCheck:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			CountedCrashEvents: []string{"crash1-guid"},
+			CountedEvents:      []string{"event1-guid", "event2-guid"},
+		}
+		gomega.Ω(store.Save(state)).Should(gomega.Succeed())
+
+		loaded, err := store.Load()
+		gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+		gomega.Ω(loaded).Should(gomega.Equal(state))
+	})
+
+	ginkgo.It("loads no state when nothing was saved yet", func() {
+		loaded, err := store.Load()
+		gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+		gomega.Ω(loaded).Should(gomega.BeNil())
+	})
+
+	ginkgo.It("fails to load a corrupt state", func() {
+		gomega.Ω(os.WriteFile(path, []byte(`{"last_check":`), 0o600)).Should(gomega.Succeed())
+
+		loaded, err := store.Load()
+		gomega.Ω(err).Should(gomega.HaveOccurred())
+		gomega.Ω(loaded).Should(gomega.BeNil())
+	})
+
+	ginkgo.It("leaves no temporary file behind when replacing the state", func() {
+		gomega.Ω(store.Save(&EventsState{CountedEvents: []string{"event1-guid"}})).Should(gomega.Succeed())
+		gomega.Ω(store.Save(&EventsState{CountedEvents: []string{"event2-guid"}})).Should(gomega.Succeed())
+
+		entries, err := os.ReadDir(dir)
+		gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+		gomega.Ω(entries).Should(gomega.HaveLen(1))
+		gomega.Ω(entries[0].Name()).Should(gomega.Equal("events.json"))
+
+		loaded, err := store.Load()
+		gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+		gomega.Ω(loaded.CountedEvents).Should(gomega.Equal([]string{"event2-guid"}))
+	})
+})
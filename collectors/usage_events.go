@@ -0,0 +1,353 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/cf_exporter/v2/models"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+type UsageEventsCollector struct {
+	namespace                                  string
+	environment                                string
+	deployment                                 string
+	orgAppMemoryMBSecondsTotalMetric           *prometheus.CounterVec
+	orgServiceInstanceSecondsTotalMetric       *prometheus.CounterVec
+	usageEventsScrapesTotalMetric              prometheus.Counter
+	usageEventsScrapeErrorsTotalMetric         prometheus.Counter
+	lastUsageEventsScrapeErrorMetric           prometheus.Gauge
+	lastUsageEventsScrapeTimestampMetric       prometheus.Gauge
+	lastUsageEventsScrapeDurationSecondsMetric prometheus.Gauge
+	state                                      *UsageEventsState
+	store                                      UsageEventsStore
+}
+
+func NewUsageEventsCollector(
+	namespace string,
+	environment string,
+	deployment string,
+	store UsageEventsStore,
+) *UsageEventsCollector {
+	orgAppMemoryMBSecondsTotalMetric := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "org",
+			Name:        "app_memory_mb_seconds_total",
+			Help:        "Total memory in MB multiplied by the number of seconds it was allocated to Cloud Foundry Application instances and tasks.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"organization_id", "space_id", "space_name"},
+	)
+
+	orgServiceInstanceSecondsTotalMetric := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "org",
+			Name:        "service_instance_seconds_total",
+			Help:        "Total number of seconds Cloud Foundry managed Service Instances existed.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+		[]string{"organization_id", "space_id", "space_name", "service_offering_name", "service_plan_id", "service_plan_name"},
+	)
+
+	usageEventsScrapesTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "usage_events_scrapes",
+			Name:        "total",
+			Help:        "Total number of scrapes for Cloud Foundry Usage Events.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	usageEventsScrapeErrorsTotalMetric := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "usage_events_scrape_errors",
+			Name:        "total",
+			Help:        "Total number of scrape errors of Cloud Foundry Usage Events.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastUsageEventsScrapeErrorMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_usage_events_scrape_error",
+			Help:        "Whether the last scrape of Usage Events metrics from Cloud Foundry resulted in an error (1 for error, 0 for success).",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastUsageEventsScrapeTimestampMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_usage_events_scrape_timestamp",
+			Help:        "Number of seconds since 1970 since last scrape of Usage Events metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	lastUsageEventsScrapeDurationSecondsMetric := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "",
+			Name:        "last_usage_events_scrape_duration_seconds",
+			Help:        "Duration of the last scrape of Usage Events metrics from Cloud Foundry.",
+			ConstLabels: prometheus.Labels{"environment": environment, "deployment": deployment},
+		},
+	)
+
+	res := &UsageEventsCollector{
+		namespace:                                  namespace,
+		environment:                                environment,
+		deployment:                                 deployment,
+		orgAppMemoryMBSecondsTotalMetric:           orgAppMemoryMBSecondsTotalMetric,
+		orgServiceInstanceSecondsTotalMetric:       orgServiceInstanceSecondsTotalMetric,
+		usageEventsScrapesTotalMetric:              usageEventsScrapesTotalMetric,
+		usageEventsScrapeErrorsTotalMetric:         usageEventsScrapeErrorsTotalMetric,
+		lastUsageEventsScrapeErrorMetric:           lastUsageEventsScrapeErrorMetric,
+		lastUsageEventsScrapeTimestampMetric:       lastUsageEventsScrapeTimestampMetric,
+		lastUsageEventsScrapeDurationSecondsMetric: lastUsageEventsScrapeDurationSecondsMetric,
+		// a fresh start replays the retained events to know what is running, but
+		// only accrues usage from now on
+		state: &UsageEventsState{
+			AccruedUntil:     time.Now(),
+			Processes:        map[string]UsageProcess{},
+			ServiceInstances: map[string]UsageServiceInstance{},
+		},
+		store: store,
+	}
+	res.loadState()
+	return res
+}
+
+func (c *UsageEventsCollector) Collect(objs *models.CFObjects, ch chan<- prometheus.Metric) {
+	errorMetric := float64(0)
+	if objs.Error != nil {
+		errorMetric = float64(1)
+		c.usageEventsScrapeErrorsTotalMetric.Inc()
+	} else {
+		c.reportUsageEventsMetrics(objs)
+		c.saveState()
+	}
+
+	c.orgAppMemoryMBSecondsTotalMetric.Collect(ch)
+	c.orgServiceInstanceSecondsTotalMetric.Collect(ch)
+	c.usageEventsScrapeErrorsTotalMetric.Collect(ch)
+	c.usageEventsScrapesTotalMetric.Inc()
+	c.usageEventsScrapesTotalMetric.Collect(ch)
+	c.lastUsageEventsScrapeErrorMetric.Set(errorMetric)
+	c.lastUsageEventsScrapeErrorMetric.Collect(ch)
+	c.lastUsageEventsScrapeTimestampMetric.Set(float64(time.Now().Unix()))
+	c.lastUsageEventsScrapeTimestampMetric.Collect(ch)
+	c.lastUsageEventsScrapeDurationSecondsMetric.Set(objs.Took)
+	c.lastUsageEventsScrapeDurationSecondsMetric.Collect(ch)
+}
+
+func (c *UsageEventsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.orgAppMemoryMBSecondsTotalMetric.Describe(ch)
+	c.orgServiceInstanceSecondsTotalMetric.Describe(ch)
+	c.usageEventsScrapesTotalMetric.Describe(ch)
+	c.usageEventsScrapeErrorsTotalMetric.Describe(ch)
+	c.lastUsageEventsScrapeErrorMetric.Describe(ch)
+	c.lastUsageEventsScrapeTimestampMetric.Describe(ch)
+	c.lastUsageEventsScrapeDurationSecondsMetric.Describe(ch)
+}
+
+// loadState
+//  1. resume from the last consumed usage events of a previous run, the downtime is accrued
+//     from the processes and service instances running when the exporter stopped
+//  2. a state without accrual time never accrued anything, only accrue from now on as for a
+//     fresh start, otherwise replaying the retained events would accrue weeks of usage at once
+func (c *UsageEventsCollector) loadState() {
+	if c.store == nil {
+		return
+	}
+	state, err := c.store.Load()
+	if err != nil {
+		log.WithError(err).Warn("unable to load usage events state, starting from scratch")
+		return
+	}
+	if state == nil {
+		return
+	}
+
+	// 1.
+	c.state = state
+	if c.state.Processes == nil {
+		c.state.Processes = map[string]UsageProcess{}
+	}
+	if c.state.ServiceInstances == nil {
+		c.state.ServiceInstances = map[string]UsageServiceInstance{}
+	}
+	// 2.
+	if c.state.AccruedUntil.IsZero() {
+		c.state.AccruedUntil = time.Now()
+	}
+}
+
+func (c *UsageEventsCollector) saveState() {
+	if c.store == nil {
+		return
+	}
+	if err := c.store.Save(c.state); err != nil {
+		log.WithError(err).Warn("unable to save usage events state")
+	}
+}
+
+// Cursors returns the last consumed app and service usage events, the next
+// scrape only fetches the events created after them
+func (c *UsageEventsCollector) Cursors() (string, string) {
+	return c.state.AppCursor, c.state.ServiceCursor
+}
+
+// reportUsageEventsMetrics
+// 1. replay the events in their creation order, accruing the usage of a process or service
+// instance until the event changing it
+// 2. accrue the usage of everything still running until now
+func (c *UsageEventsCollector) reportUsageEventsMetrics(objs *models.CFObjects) {
+	// 1.
+	for _, event := range objs.AppUsageEvents {
+		c.applyAppUsageEvent(event)
+		c.state.AppCursor = event.GUID
+	}
+	for _, event := range objs.ServiceUsageEvents {
+		c.applyServiceUsageEvent(event)
+		c.state.ServiceCursor = event.GUID
+	}
+
+	// 2.
+	now := time.Now()
+	for guid, process := range c.state.Processes {
+		c.accrueProcess(&process, now)
+		c.state.Processes[guid] = process
+	}
+	for guid, instance := range c.state.ServiceInstances {
+		c.accrueServiceInstance(&instance, now)
+		c.state.ServiceInstances[guid] = instance
+	}
+	if now.After(c.state.AccruedUntil) {
+		c.state.AccruedUntil = now
+	}
+}
+
+// applyAppUsageEvent
+//  1. tasks are tracked by their own guid, processes by the process guid which older
+//     events do not carry, fallback to the application guid
+//  2. usage before the last accrual was already accounted for, ie: when replaying the
+//     retained events on the first run
+func (c *UsageEventsCollector) applyAppUsageEvent(event models.AppUsageEvent) {
+	var running, task bool
+	switch event.State.Current {
+	case "STARTED":
+		running = true
+	case "STOPPED":
+	case "TASK_STARTED":
+		running, task = true, true
+	case "TASK_STOPPED":
+		task = true
+	default:
+		return
+	}
+
+	// 1.
+	key := event.Process.GUID
+	if task {
+		key = event.Task.GUID
+	}
+	if key == "" {
+		key = event.App.GUID
+	}
+
+	if process, ok := c.state.Processes[key]; ok {
+		c.accrueProcess(&process, event.CreatedAt)
+		delete(c.state.Processes, key)
+	}
+	if !running {
+		return
+	}
+
+	// 2.
+	since := event.CreatedAt
+	if since.Before(c.state.AccruedUntil) {
+		since = c.state.AccruedUntil
+	}
+	instances := event.InstanceCount.Current
+	if instances == 0 && task {
+		instances = 1
+	}
+	c.state.Processes[key] = UsageProcess{
+		OrganizationID: event.Org.GUID,
+		SpaceID:        event.Space.GUID,
+		SpaceName:      event.Space.Name,
+		MemoryMB:       event.MemoryInMBPerInstance.Current,
+		Instances:      instances,
+		Since:          since,
+	}
+}
+
+// applyServiceUsageEvent
+// 1. user provided service instances are not billed
+// 2. usage before the last accrual was already accounted for, see applyAppUsageEvent
+func (c *UsageEventsCollector) applyServiceUsageEvent(event models.ServiceUsageEvent) {
+	// 1.
+	if event.ServiceInstance.Type == "user_provided_service_instance" {
+		return
+	}
+
+	key := event.ServiceInstance.GUID
+	if instance, ok := c.state.ServiceInstances[key]; ok {
+		c.accrueServiceInstance(&instance, event.CreatedAt)
+		delete(c.state.ServiceInstances, key)
+	}
+	if event.State != "CREATED" && event.State != "UPDATED" {
+		return
+	}
+
+	// 2.
+	since := event.CreatedAt
+	if since.Before(c.state.AccruedUntil) {
+		since = c.state.AccruedUntil
+	}
+	c.state.ServiceInstances[key] = UsageServiceInstance{
+		OrganizationID:      event.Org.GUID,
+		SpaceID:             event.Space.GUID,
+		SpaceName:           event.Space.Name,
+		ServiceOfferingName: event.ServiceOffering.Name,
+		ServicePlanID:       event.ServicePlan.GUID,
+		ServicePlanName:     event.ServicePlan.Name,
+		Since:               since,
+	}
+}
+
+func (c *UsageEventsCollector) accrueProcess(process *UsageProcess, until time.Time) {
+	if !until.After(process.Since) {
+		return
+	}
+	seconds := until.Sub(process.Since).Seconds()
+	c.orgAppMemoryMBSecondsTotalMetric.WithLabelValues(
+		process.OrganizationID,
+		process.SpaceID,
+		process.SpaceName,
+	).Add(float64(process.MemoryMB*process.Instances) * seconds)
+	process.Since = until
+}
+
+func (c *UsageEventsCollector) accrueServiceInstance(instance *UsageServiceInstance, until time.Time) {
+	if !until.After(instance.Since) {
+		return
+	}
+	c.orgServiceInstanceSecondsTotalMetric.WithLabelValues(
+		instance.OrganizationID,
+		instance.SpaceID,
+		instance.SpaceName,
+		instance.ServiceOfferingName,
+		instance.ServicePlanID,
+		instance.ServicePlanName,
+	).Add(until.Sub(instance.Since).Seconds())
+	instance.Since = until
+}
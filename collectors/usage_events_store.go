@@ -0,0 +1,43 @@
+package collectors
+
+import "time"
+
+// UsageProcess is a process or task running at the last consumed app usage
+// event, Since is the time its usage was accrued until
+type UsageProcess struct {
+	OrganizationID string    `json:"organization_id"`
+	SpaceID        string    `json:"space_id"`
+	SpaceName      string    `json:"space_name"`
+	MemoryMB       int       `json:"memory_mb"`
+	Instances      int       `json:"instances"`
+	Since          time.Time `json:"since"`
+}
+
+// UsageServiceInstance is a managed service instance existing at the last
+// consumed service usage event, Since is the time its usage was accrued until
+type UsageServiceInstance struct {
+	OrganizationID      string    `json:"organization_id"`
+	SpaceID             string    `json:"space_id"`
+	SpaceName           string    `json:"space_name"`
+	ServiceOfferingName string    `json:"service_offering_name"`
+	ServicePlanID       string    `json:"service_plan_id"`
+	ServicePlanName     string    `json:"service_plan_name"`
+	Since               time.Time `json:"since"`
+}
+
+// UsageEventsState holds the progress of the usage events collector which
+// must survive exporter restarts for usage to be accrued exactly once
+type UsageEventsState struct {
+	AccruedUntil     time.Time                       `json:"accrued_until"`
+	AppCursor        string                          `json:"app_cursor"`
+	ServiceCursor    string                          `json:"service_cursor"`
+	Processes        map[string]UsageProcess         `json:"processes"`
+	ServiceInstances map[string]UsageServiceInstance `json:"service_instances"`
+}
+
+// UsageEventsStore persists the usage events collector state, Load returns a
+// nil state when nothing was saved yet
+type UsageEventsStore interface {
+	Load() (*UsageEventsState, error)
+	Save(state *UsageEventsState) error
+}
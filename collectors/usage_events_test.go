@@ -0,0 +1,142 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/cloudfoundry/cf_exporter/v2/models"
+)
+
+func counterValue(vec *prometheus.CounterVec, labels ...string) float64 {
+	metric := &dto.Metric{}
+	gomega.Ω(vec.WithLabelValues(labels...).Write(metric)).Should(gomega.Succeed())
+	return metric.GetCounter().GetValue()
+}
+
+func appUsageEvent(state string, at time.Time, processGUID string, taskGUID string, memory int, instances int) models.AppUsageEvent {
+	return models.AppUsageEvent{
+		GUID:                  "event-" + state,
+		CreatedAt:             at,
+		State:                 models.UsageEventState{Current: state},
+		App:                   models.UsageEventResource{GUID: "app1-guid"},
+		Process:               models.UsageEventResource{GUID: processGUID},
+		Task:                  models.UsageEventResource{GUID: taskGUID},
+		Space:                 models.UsageEventResource{GUID: "space1-guid", Name: "space1"},
+		Org:                   models.UsageEventResource{GUID: "org1-guid"},
+		MemoryInMBPerInstance: models.UsageEventValue{Current: memory},
+		InstanceCount:         models.UsageEventValue{Current: instances},
+	}
+}
+
+func serviceUsageEvent(state string, at time.Time, instanceType string, planGUID string) models.ServiceUsageEvent {
+	return models.ServiceUsageEvent{
+		GUID:            "event-" + state,
+		CreatedAt:       at,
+		State:           state,
+		Space:           models.UsageEventResource{GUID: "space1-guid", Name: "space1"},
+		Org:             models.UsageEventResource{GUID: "org1-guid"},
+		ServiceInstance: models.UsageEventResource{GUID: "instance1-guid", Type: instanceType},
+		ServiceOffering: models.UsageEventResource{Name: "offering1"},
+		ServicePlan:     models.UsageEventResource{GUID: planGUID, Name: planGUID},
+	}
+}
+
+var _ = ginkgo.Describe("UsageEventsCollector", func() {
+	var (
+		collector *UsageEventsCollector
+		start     time.Time
+	)
+
+	ginkgo.BeforeEach(func() {
+		collector = NewUsageEventsCollector("cf", "env", "deployment", nil)
+		start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		collector.state.AccruedUntil = start
+	})
+
+	ginkgo.Describe("applyAppUsageEvent", func() {
+		ginkgo.It("accrues a process from its start until its stop", func() {
+			collector.applyAppUsageEvent(appUsageEvent("STARTED", start.Add(10*time.Second), "process1-guid", "", 256, 2))
+			collector.applyAppUsageEvent(appUsageEvent("STOPPED", start.Add(70*time.Second), "process1-guid", "", 256, 2))
+
+			gomega.Ω(collector.state.Processes).Should(gomega.BeEmpty())
+			gomega.Ω(counterValue(collector.orgAppMemoryMBSecondsTotalMetric, "org1-guid", "space1-guid", "space1")).Should(gomega.Equal(float64(256 * 2 * 60)))
+		})
+
+		ginkgo.It("accrues the previous instances until a scale", func() {
+			collector.applyAppUsageEvent(appUsageEvent("STARTED", start, "process1-guid", "", 256, 1))
+			collector.applyAppUsageEvent(appUsageEvent("STARTED", start.Add(10*time.Second), "process1-guid", "", 256, 3))
+			collector.applyAppUsageEvent(appUsageEvent("STOPPED", start.Add(20*time.Second), "process1-guid", "", 256, 3))
+
+			gomega.Ω(counterValue(collector.orgAppMemoryMBSecondsTotalMetric, "org1-guid", "space1-guid", "space1")).Should(gomega.Equal(float64(256*1*10 + 256*3*10)))
+		})
+
+		ginkgo.It("accrues a task as one instance tracked apart from the application process", func() {
+			collector.applyAppUsageEvent(appUsageEvent("STARTED", start, "process1-guid", "", 256, 1))
+			collector.applyAppUsageEvent(appUsageEvent("TASK_STARTED", start.Add(10*time.Second), "", "task1-guid", 512, 0))
+			collector.applyAppUsageEvent(appUsageEvent("TASK_STOPPED", start.Add(40*time.Second), "", "task1-guid", 512, 0))
+
+			gomega.Ω(collector.state.Processes).Should(gomega.HaveKey("process1-guid"))
+			gomega.Ω(collector.state.Processes).ShouldNot(gomega.HaveKey("task1-guid"))
+			gomega.Ω(counterValue(collector.orgAppMemoryMBSecondsTotalMetric, "org1-guid", "space1-guid", "space1")).Should(gomega.Equal(float64(512 * 30)))
+		})
+
+		ginkgo.It("does not accrue usage replayed before the last accrual", func() {
+			collector.applyAppUsageEvent(appUsageEvent("STARTED", start.Add(-time.Hour), "process1-guid", "", 256, 1))
+			collector.applyAppUsageEvent(appUsageEvent("STARTED", start.Add(-time.Hour), "process2-guid", "", 256, 1))
+			collector.applyAppUsageEvent(appUsageEvent("STOPPED", start.Add(-time.Minute), "process2-guid", "", 256, 1))
+			collector.applyAppUsageEvent(appUsageEvent("STOPPED", start.Add(30*time.Second), "process1-guid", "", 256, 1))
+
+			gomega.Ω(collector.state.Processes).Should(gomega.BeEmpty())
+			gomega.Ω(counterValue(collector.orgAppMemoryMBSecondsTotalMetric, "org1-guid", "space1-guid", "space1")).Should(gomega.Equal(float64(256 * 30)))
+		})
+
+		ginkgo.It("only accrues from now on when starting without state", func() {
+			collector = NewUsageEventsCollector("cf", "env", "deployment", nil)
+			collector.reportUsageEventsMetrics(&models.CFObjects{
+				AppUsageEvents: []models.AppUsageEvent{
+					appUsageEvent("STARTED", time.Now().Add(-30*24*time.Hour), "process1-guid", "", 256, 1),
+				},
+			})
+
+			gomega.Ω(collector.state.Processes).Should(gomega.HaveKey("process1-guid"))
+			gomega.Ω(collector.state.AppCursor).Should(gomega.Equal("event-STARTED"))
+			gomega.Ω(counterValue(collector.orgAppMemoryMBSecondsTotalMetric, "org1-guid", "space1-guid", "space1")).Should(gomega.BeNumerically("<", 256*60))
+		})
+	})
+
+	ginkgo.Describe("applyServiceUsageEvent", func() {
+		ginkgo.It("accrues a managed instance from its creation until its deletion", func() {
+			collector.applyServiceUsageEvent(serviceUsageEvent("CREATED", start.Add(10*time.Second), "managed_service_instance", "plan1-guid"))
+			collector.applyServiceUsageEvent(serviceUsageEvent("DELETED", start.Add(100*time.Second), "managed_service_instance", "plan1-guid"))
+
+			gomega.Ω(collector.state.ServiceInstances).Should(gomega.BeEmpty())
+			gomega.Ω(counterValue(collector.orgServiceInstanceSecondsTotalMetric, "org1-guid", "space1-guid", "space1", "offering1", "plan1-guid", "plan1-guid")).Should(gomega.Equal(float64(90)))
+		})
+
+		ginkgo.It("accrues the previous plan until an update", func() {
+			collector.applyServiceUsageEvent(serviceUsageEvent("CREATED", start, "managed_service_instance", "plan1-guid"))
+			collector.applyServiceUsageEvent(serviceUsageEvent("UPDATED", start.Add(20*time.Second), "managed_service_instance", "plan2-guid"))
+			collector.applyServiceUsageEvent(serviceUsageEvent("DELETED", start.Add(50*time.Second), "managed_service_instance", "plan2-guid"))
+
+			gomega.Ω(counterValue(collector.orgServiceInstanceSecondsTotalMetric, "org1-guid", "space1-guid", "space1", "offering1", "plan1-guid", "plan1-guid")).Should(gomega.Equal(float64(20)))
+			gomega.Ω(counterValue(collector.orgServiceInstanceSecondsTotalMetric, "org1-guid", "space1-guid", "space1", "offering1", "plan2-guid", "plan2-guid")).Should(gomega.Equal(float64(30)))
+		})
+
+		ginkgo.It("ignores user provided instances", func() {
+			collector.applyServiceUsageEvent(serviceUsageEvent("CREATED", start, "user_provided_service_instance", ""))
+
+			gomega.Ω(collector.state.ServiceInstances).Should(gomega.BeEmpty())
+		})
+
+		ginkgo.It("does not accrue usage replayed before the last accrual", func() {
+			collector.applyServiceUsageEvent(serviceUsageEvent("CREATED", start.Add(-time.Hour), "managed_service_instance", "plan1-guid"))
+			collector.applyServiceUsageEvent(serviceUsageEvent("DELETED", start.Add(30*time.Second), "managed_service_instance", "plan1-guid"))
+
+			gomega.Ω(counterValue(collector.orgServiceInstanceSecondsTotalMetric, "org1-guid", "space1-guid", "space1", "offering1", "plan1-guid", "plan1-guid")).Should(gomega.Equal(float64(30)))
+		})
+	})
+})
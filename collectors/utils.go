@@ -1,7 +1,12 @@
 package collectors
 
 import (
+	"slices"
+	"strconv"
+	"strings"
+
 	"code.cloudfoundry.org/cli/v8/types"
+	"github.com/cloudfoundry/cf_exporter/v2/models"
 )
 
 func BoolToFloat(val *bool) float64 {
@@ -23,3 +28,66 @@ func NullIntToFloat(val *types.NullInt) float64 {
 	}
 	return float64(val.Value)
 }
+
+// CompareVersions compares two semantic version strings numerically, ignoring
+// a leading 'v' and any build metadata. It returns -1, 0 or 1 when a is lower
+// than, equal to or greater than b. A pre-release is lower than the release it
+// precedes, two pre-releases of the same release compare as equal.
+func CompareVersions(a string, b string) int {
+	ca, preA := splitVersion(a)
+	cb, preB := splitVersion(b)
+	pa := strings.Split(ca, ".")
+	pb := strings.Split(cb, ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		va, vb := 0, 0
+		if i < len(pa) {
+			va, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			vb, _ = strconv.Atoi(pb[i])
+		}
+		if va < vb {
+			return -1
+		}
+		if va > vb {
+			return 1
+		}
+	}
+	if preA && !preB {
+		return -1
+	}
+	if !preA && preB {
+		return 1
+	}
+	return 0
+}
+
+// splitVersion returns the dotted numeric core of a version and whether it
+// carries a pre-release suffix.
+func splitVersion(version string) (string, bool) {
+	version = strings.TrimPrefix(version, "v")
+	version, _, _ = strings.Cut(version, "+")
+	core, _, pre := strings.Cut(version, "-")
+	return core, pre
+}
+
+// IsDeprecatedStack tells whether a stack is deprecated by the cloud
+// controller or listed in the given deprecated stack names.
+func IsDeprecatedStack(stack models.Stack, deprecated []string) bool {
+	if stack.State == "DEPRECATED" {
+		return true
+	}
+	return slices.Contains(deprecated, stack.Name)
+}
+
+// SplitList splits a comma separated list, trimming the spaces around each
+// item and dropping the empty ones.
+func SplitList(list string) []string {
+	res := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
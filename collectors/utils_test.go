@@ -0,0 +1,86 @@
+package collectors
+
+import (
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Utils", func() {
+
+	ginkgo.Describe("CompareVersions", func() {
+		cases := []struct {
+			a        string
+			b        string
+			expected int
+		}{
+			{"1.2.3", "1.2.3", 0},
+			{"v1.2.3", "1.2.3", 0},
+			{"1.2.3", "1.2.4", -1},
+			{"1.10.0", "1.9.0", 1},
+			{"1.2", "1.2.0", 0},
+			{"1.2", "1.2.1", -1},
+			{"2", "1.99.99", 1},
+			{"1.2.3-rc1", "1.2.3", -1},
+			{"1.2.3", "1.2.3-rc1", 1},
+			{"1.2.3-rc1", "1.2.3-rc2", 0},
+			{"1.2.3-rc1", "1.2.2", 1},
+			{"1.2.3+build.7", "1.2.3", 0},
+			{"1.2.3-rc1+build.7", "1.2.3", -1},
+			{"", "", 0},
+			{"", "1.0.0", -1},
+		}
+		for _, c := range cases {
+			c := c
+			ginkgo.It("compares "+c.a+" with "+c.b, func() {
+				gomega.Expect(CompareVersions(c.a, c.b)).To(gomega.Equal(c.expected))
+			})
+		}
+	})
+
+	ginkgo.Describe("SplitList", func() {
+		cases := []struct {
+			list     string
+			expected []string
+		}{
+			{"", []string{}},
+			{"cflinuxfs3", []string{"cflinuxfs3"}},
+			{"cflinuxfs3,windows2016", []string{"cflinuxfs3", "windows2016"}},
+			{" cflinuxfs3 , windows2016 ", []string{"cflinuxfs3", "windows2016"}},
+			{"cflinuxfs3,,windows2016,", []string{"cflinuxfs3", "windows2016"}},
+			{" , ", []string{}},
+		}
+		for _, c := range cases {
+			c := c
+			ginkgo.It("splits '"+c.list+"'", func() {
+				gomega.Expect(SplitList(c.list)).To(gomega.Equal(c.expected))
+			})
+		}
+	})
+
+	ginkgo.Describe("buildpackFilenameVersionRegexp", func() {
+		cases := []struct {
+			filename string
+			expected string
+		}{
+			{"ruby_buildpack-cached-cflinuxfs4-v1.10.11.zip", "1.10.11"},
+			{"java-buildpack-offline-v4.65.0.zip", "4.65.0"},
+			{"go_buildpack-cflinuxfs4-v1.10.20-rc1.zip", "1.10.20"},
+			{"nodejs_buildpack-cflinuxfs4-v1.8.2+build.3.zip", "1.8.2"},
+			{"custom-1.2-tools-v3.4.5.tgz", "3.4.5"},
+			{"staticfile_buildpack-v1.6.0.tar.gz", "1.6.0"},
+			{"1.2.3.zip", "1.2.3"},
+			{"binary_buildpack-cflinuxfs4.zip", ""},
+			{"buildpack-v2.zip", ""},
+		}
+		for _, c := range cases {
+			c := c
+			ginkgo.It("extracts the version of "+c.filename, func() {
+				version := ""
+				if match := buildpackFilenameVersionRegexp.FindStringSubmatch(c.filename); match != nil {
+					version = match[1]
+				}
+				gomega.Expect(version).To(gomega.Equal(c.expected))
+			})
+		}
+	})
+})
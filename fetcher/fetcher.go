@@ -1,6 +1,8 @@
 package fetcher
 
 import (
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
@@ -15,13 +17,18 @@ var (
 		Key:    ccv3.PerPage,
 		Values: []string{"5000"},
 	}
-	SortDesc = ccv3.Query{
+	SortAsc = ccv3.Query{
 		Key:    ccv3.OrderBy,
-		Values: []string{"-created_at"},
+		Values: []string{"created_at"},
 	}
-	DefaultTaskStates = []string{"PENDING", "RUNNING", "CANCELING"}
+	DefaultTaskStates     = []string{"PENDING", "RUNNING", "CANCELING"}
+	DefaultEventsLookback = 15 * time.Minute
 )
 
+// DependentChunkSize is the number of objects asked by each job of
+// workInitDependent
+const DependentChunkSize = 50
+
 type CFConfig struct {
 	SkipSSLValidation bool   `yaml:"skip_ssl_validation"`
 	URL               string `yaml:"url"`
@@ -30,6 +37,12 @@ type CFConfig struct {
 	Username          string `yaml:"username"`
 	Password          string `yaml:"password"`
 	TaskStates        []string
+	EventTypes        []string
+	EventTargetTypes  []string
+	EventsLookback    time.Duration
+	EventsSince       time.Time
+	AppUsageAfter     string
+	ServiceUsageAfter string
 }
 
 type Fetcher struct {
@@ -63,30 +76,45 @@ func (c *Fetcher) GetObjects() *models.CFObjects {
 func (c *Fetcher) workInit() {
 	c.worker.Reset()
 	c.worker.Push("info", c.fetchInfo)
-	c.worker.PushIf("organizations", c.fetchOrgs, filters.Applications, filters.Organizations)
-	c.worker.PushIf("org_quotas", c.fetchOrgQuotas, filters.Organizations)
-	c.worker.PushIf("spaces", c.fetchSpaces, filters.Applications, filters.Spaces)
-	c.worker.PushIf("space_quotas", c.fetchSpaceQuotas, filters.Spaces)
-	c.worker.PushIf("applications", c.fetchApplications, filters.Applications)
-	c.worker.PushIf("droplets", c.fetchDroplets, filters.Droplets)
-	c.worker.PushIf("domains", c.fetchDomains, filters.Domains)
-	c.worker.PushIf("process", c.fetchProcesses, filters.Applications)
+	c.worker.PushIf("organizations", c.fetchOrgs, filters.Applications, filters.Organizations, filters.Builds, filters.Routes, filters.SecurityPosture, filters.IsolationSegments, filters.Roles)
+	c.worker.PushIf("org_quotas", c.fetchOrgQuotas, filters.Organizations, filters.SecurityPosture)
+	c.worker.PushIf("spaces", c.fetchSpaces, filters.Applications, filters.Spaces, filters.Builds, filters.Routes, filters.ServiceInstances, filters.SecurityPosture, filters.IsolationSegments, filters.Roles)
+	c.worker.PushIf("space_quotas", c.fetchSpaceQuotas, filters.Spaces, filters.SecurityPosture)
+	c.worker.PushIf("applications", c.fetchApplications, filters.Applications, filters.Droplets, filters.Deployments, filters.Builds, filters.Routes, filters.SecurityPosture, filters.IsolationSegments, filters.Stacks, filters.Buildpacks)
+	c.worker.PushIf("droplets", c.fetchDroplets, filters.Droplets, filters.Buildpacks)
+	c.worker.PushIf("deployments", c.fetchDeployments, filters.Deployments)
+	c.worker.PushIf("builds", c.fetchBuilds, filters.Builds)
+	c.worker.PushIf("domains", c.fetchDomains, filters.Domains, filters.Routes)
+	c.worker.PushIf("feature_flags", c.fetchFeatureFlags, filters.FeatureFlags)
+	c.worker.PushIf("process", c.fetchProcesses, filters.Applications, filters.IsolationSegments, filters.Stacks)
 	c.worker.PushIf("routes", c.fetchRoutes, filters.Routes)
 	c.worker.PushIf("route_services", c.fetchRouteServices, filters.Routes)
-	c.worker.PushIf("security_groups", c.fetchSecurityGroups, filters.SecurityGroups)
-	c.worker.PushIf("stacks", c.fetchStacks, filters.Stacks)
-	c.worker.PushIf("buildpacks", c.fetchBuildpacks, filters.Buildpacks)
+	c.worker.PushIf("security_groups", c.fetchSecurityGroups, filters.SecurityGroups, filters.SecurityPosture)
+	c.worker.PushIf("stacks", c.fetchStacks, filters.Applications, filters.Stacks, filters.SecurityPosture)
+	c.worker.PushIf("buildpacks", c.fetchBuildpacks, filters.Buildpacks, filters.Droplets)
 	c.worker.PushIf("tasks", c.fetchTasks, filters.Tasks)
-	c.worker.PushIf("service_brokers", c.fetchServiceBrokers, filters.Services)
-	c.worker.PushIf("service_offerings", c.fetchServiceOfferings, filters.Services)
-	c.worker.PushIf("service_instances", c.fetchServiceInstances, filters.ServiceInstances)
-	c.worker.PushIf("service_plans", c.fetchServicePlans, filters.ServicePlans)
+	c.worker.PushIf("service_brokers", c.fetchServiceBrokers, filters.Services, filters.ServiceBrokers)
+	c.worker.PushIf("service_offerings", c.fetchServiceOfferings, filters.Services, filters.ServiceBrokers, filters.ServiceInstances)
+	c.worker.PushIf("service_instances", c.fetchServiceInstances, filters.ServiceInstances, filters.ServiceBrokers, filters.ServicePlans)
+	c.worker.PushIf("service_plans", c.fetchServicePlans, filters.ServicePlans, filters.ServiceBrokers, filters.ServiceInstances)
 	c.worker.PushIf("segments", c.fetchIsolationSegments, filters.IsolationSegments)
-	c.worker.PushIf("service_bindings", c.fetchServiceBindings, filters.ServiceBindings)
+	c.worker.PushIf("service_bindings", c.fetchServiceBindings, filters.ServiceBindings, filters.SecurityPosture)
 	c.worker.PushIf("service_route_bindings", c.fetchServiceRouteBindings, filters.ServiceRouteBindings)
-	c.worker.PushIf("users", c.fetchUsers, filters.Events)
+	c.worker.PushIf("users", c.fetchUsers, filters.Events, filters.Roles)
+	c.worker.PushIf("roles", c.fetchRoles, filters.Roles)
 	c.worker.PushIf("events", c.fetchEvents, filters.Events)
 	c.worker.PushIf("actual_lrps", c.fetchActualLRPs, filters.ActualLRPs)
+	c.worker.PushIf("app_usage_events", c.fetchAppUsageEvents, filters.UsageEvents)
+	c.worker.PushIf("service_usage_events", c.fetchServiceUsageEvents, filters.UsageEvents)
+}
+
+// workInitDependent plans the jobs asking details of objects already fetched
+// by the jobs of workInit, instead of listing them again
+func (c *Fetcher) workInitDependent(entry *models.CFObjects) {
+	c.worker.Reset()
+	c.worker.PushChunksIf("service_instance_shares", shareableServiceInstances(entry), DependentChunkSize, c.fetchServiceInstanceShares, filters.ServiceInstances)
+	c.worker.PushChunksIf("ssh_enabled", slices.Sorted(maps.Keys(entry.Spaces)), DependentChunkSize, c.fetchSSHEnabled, filters.SecurityPosture)
+	c.worker.PushIf("segment_placements", c.fetchIsolationSegmentPlacements, filters.IsolationSegments)
 }
 
 func (c *Fetcher) fetch() *models.CFObjects {
@@ -110,6 +138,13 @@ func (c *Fetcher) fetch() *models.CFObjects {
 
 	c.workInit()
 
+	result.Error = c.worker.Do(session, bbs, result)
+	if result.Error != nil {
+		return result
+	}
+
+	c.workInitDependent(result)
+
 	result.Error = c.worker.Do(session, bbs, result)
 	return result
 }
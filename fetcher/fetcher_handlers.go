@@ -1,12 +1,16 @@
 package fetcher
 
 import (
+	"maps"
 	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	models2 "code.cloudfoundry.org/bbs/models"
 
 	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
 	"code.cloudfoundry.org/cli/v8/resources"
 	"github.com/cloudfoundry/cf_exporter/v2/models"
 	log "github.com/sirupsen/logrus"
@@ -121,9 +125,9 @@ func (c *Fetcher) fetchProcesses(session *SessionExt, _ *BBSClient, entry *model
 }
 
 func (c *Fetcher) fetchRoutes(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
-	routes, _, err := session.V3().GetRoutes(LargeQuery)
+	routes, err := session.GetRoutes()
 	if err == nil {
-		loadIndex(entry.Routes, routes, func(r resources.Route) string { return r.GUID })
+		loadIndex(entry.Routes, routes, func(r models.Route) string { return r.GUID })
 	}
 	return err
 }
@@ -145,25 +149,50 @@ func (c *Fetcher) fetchSecurityGroups(session *SessionExt, _ *BBSClient, entry *
 }
 
 func (c *Fetcher) fetchDroplets(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
-	droplets, _, err := session.V3().GetDroplets(LargeQuery)
+	droplets, err := session.GetDroplets()
 	if err == nil {
-		loadIndex(entry.Droplets, droplets, func(r resources.Droplet) string { return r.GUID })
+		loadIndex(entry.Droplets, droplets, func(r models.Droplet) string { return r.GUID })
+	}
+	return err
+}
+
+func (c *Fetcher) fetchDeployments(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
+	deployments, _, err := session.V3().GetDeployments(LargeQuery)
+	if err == nil {
+		loadIndex(entry.Deployments, deployments, func(r resources.Deployment) string { return r.GUID })
+	}
+	return err
+}
+
+func (c *Fetcher) fetchBuilds(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
+	builds, err := session.GetBuilds()
+	if err == nil {
+		loadIndex(entry.Builds, builds, func(r models.Build) string { return r.GUID })
 	}
 	return err
 }
 
 func (c *Fetcher) fetchStacks(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
-	stacks, _, err := session.V3().GetStacks(LargeQuery)
+	stacks, err := session.GetStacks()
 	if err == nil {
-		loadIndex(entry.Stacks, stacks, func(r resources.Stack) string { return r.GUID })
+		loadIndex(entry.Stacks, stacks, func(r models.Stack) string { return r.GUID })
+		loadIndex(entry.StacksByName, stacks, func(r models.Stack) string { return r.Name })
+	}
+	return err
+}
+
+func (c *Fetcher) fetchFeatureFlags(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
+	flags, err := session.GetFeatureFlags()
+	if err == nil {
+		loadIndex(entry.FeatureFlags, flags, func(r models.FeatureFlag) string { return r.Name })
 	}
 	return err
 }
 
 func (c *Fetcher) fetchBuildpacks(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
-	buildpacks, _, err := session.V3().GetBuildpacks(LargeQuery)
+	buildpacks, err := session.GetBuildpacks()
 	if err == nil {
-		loadIndex(entry.Buildpacks, buildpacks, func(r resources.Buildpack) string { return r.GUID })
+		loadIndex(entry.Buildpacks, buildpacks, func(r models.Buildpack) string { return r.GUID })
 	}
 	return err
 }
@@ -185,9 +214,9 @@ func (c *Fetcher) fetchServiceBrokers(session *SessionExt, _ *BBSClient, entry *
 }
 
 func (c *Fetcher) fetchServiceOfferings(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
-	serviceofferings, _, err := session.V3().GetServiceOfferings(LargeQuery)
+	serviceofferings, err := session.GetServiceOfferings()
 	if err == nil {
-		loadIndex(entry.ServiceOfferings, serviceofferings, func(r resources.ServiceOffering) string { return r.GUID })
+		loadIndex(entry.ServiceOfferings, serviceofferings, func(r models.ServiceOffering) string { return r.GUID })
 	}
 	return err
 }
@@ -209,9 +238,9 @@ func (c *Fetcher) fetchServicePlans(session *SessionExt, _ *BBSClient, entry *mo
 }
 
 func (c *Fetcher) fetchServiceBindings(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
-	bindings, _, err := session.V3().GetServiceCredentialBindings(LargeQuery)
+	bindings, err := session.GetServiceCredentialBindings()
 	if err == nil {
-		loadIndex(entry.ServiceBindings, bindings, func(r resources.ServiceCredentialBinding) string { return r.GUID })
+		loadIndex(entry.ServiceBindings, bindings, func(r models.ServiceCredentialBinding) string { return r.GUID })
 	}
 	return err
 }
@@ -224,6 +253,94 @@ func (c *Fetcher) fetchServiceRouteBindings(session *SessionExt, _ *BBSClient, e
 	return err
 }
 
+// askEach asks the details of each given object and only logs the failures,
+// the objects were fetched earlier in the scrape and may have been deleted
+// since, which must not fail the whole scrape
+func askEach(guids []string, what string, ask func(guid string) error) {
+	for _, guid := range guids {
+		if err := ask(guid); err != nil {
+			log.Warnf("could not fetch %s of '%s': %s", what, guid, err)
+		}
+	}
+}
+
+// shareableServiceInstances
+// 1. only managed instances of shareable offerings can be shared
+func shareableServiceInstances(entry *models.CFObjects) []string {
+	res := []string{}
+	for _, instance := range entry.ServiceInstances {
+		// 1.
+		if instance.Type != resources.ManagedServiceInstance {
+			continue
+		}
+		plan, ok := entry.ServicePlans[instance.ServicePlanGUID]
+		if !ok {
+			continue
+		}
+		if offering, ok := entry.ServiceOfferings[plan.ServiceOfferingGUID]; ok && offering.AllowsInstanceSharing {
+			res = append(res, instance.GUID)
+		}
+	}
+	return res
+}
+
+// fetchServiceInstanceShares
+// 1. the cloud controller has no list endpoint for shared spaces, ask each instance
+func (c *Fetcher) fetchServiceInstanceShares(guids []string) WorkHandler {
+	return func(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
+		// 1.
+		askEach(guids, "service instance shared spaces", func(guid string) error {
+			shares, err := session.GetServiceInstanceShares(guid)
+			if err == nil {
+				c.Lock()
+				entry.SharedSpaces[guid] = shares
+				c.Unlock()
+			}
+			return err
+		})
+		return nil
+	}
+}
+
+// fetchSSHEnabled
+//  1. the cloud controller has no list endpoint for ssh features, ask each space
+//  2. an application can only be accessed with ssh when its space allows it,
+//     only ask the applications of such spaces
+func (c *Fetcher) fetchSSHEnabled(guids []string) WorkHandler {
+	return func(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
+		enabled := map[string]bool{}
+		// 1.
+		askEach(guids, "space ssh feature", func(guid string) error {
+			feature, _, err := session.V3().GetSpaceFeature(guid, "ssh")
+			if err == nil {
+				enabled[guid] = feature
+				c.Lock()
+				entry.SSHSpaces[guid] = feature
+				c.Unlock()
+			}
+			return err
+		})
+
+		// 2.
+		apps := []string{}
+		for guid, app := range entry.Apps {
+			if enabled[app.Relationships[constant.RelationshipTypeSpace].GUID] {
+				apps = append(apps, guid)
+			}
+		}
+		askEach(apps, "application ssh feature", func(guid string) error {
+			ssh, _, err := session.V3().GetSSHEnabled(guid)
+			if err == nil {
+				c.Lock()
+				entry.SSHApps[guid] = ssh.Enabled
+				c.Unlock()
+			}
+			return err
+		})
+		return nil
+	}
+}
+
 func (c *Fetcher) fetchIsolationSegments(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
 	segments, _, err := session.V3().GetIsolationSegments()
 	if err == nil {
@@ -232,6 +349,52 @@ func (c *Fetcher) fetchIsolationSegments(session *SessionExt, _ *BBSClient, entr
 	return err
 }
 
+// fetchIsolationSegmentPlacements
+//  1. the cloud controller has no list endpoint for entitlements and space
+//     assignments, ask each segment and only keep the fetched organizations
+//     and spaces
+//  2. only entitled organizations may have a default segment, ask each of
+//     them once
+func (c *Fetcher) fetchIsolationSegmentPlacements(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
+	entitled := map[string]bool{}
+	// 1.
+	askEach(slices.Collect(maps.Keys(entry.Segments)), "isolation segment placements", func(guid string) error {
+		orgs, err := session.GetIsolationSegmentRelationship(guid, "organizations")
+		if err != nil {
+			return err
+		}
+		spaces, err := session.GetIsolationSegmentRelationship(guid, "spaces")
+		if err != nil {
+			return err
+		}
+
+		placement := models.SegmentPlacement{}
+		for _, orgGUID := range orgs {
+			if _, ok := entry.Orgs[orgGUID]; ok {
+				placement.OrganizationGUIDs = append(placement.OrganizationGUIDs, orgGUID)
+				entitled[orgGUID] = true
+			}
+		}
+		for _, spaceGUID := range spaces {
+			if _, ok := entry.Spaces[spaceGUID]; ok {
+				placement.SpaceGUIDs = append(placement.SpaceGUIDs, spaceGUID)
+			}
+		}
+		entry.SegmentPlacements[guid] = placement
+		return nil
+	})
+
+	// 2.
+	askEach(slices.Collect(maps.Keys(entitled)), "organization default isolation segment", func(guid string) error {
+		rel, _, err := session.V3().GetOrganizationDefaultIsolationSegment(guid)
+		if err == nil && rel.GUID != "" {
+			entry.OrgDefaultSegments[guid] = rel.GUID
+		}
+		return err
+	})
+	return nil
+}
+
 func (c *Fetcher) fetchUsers(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
 	users, _, err := session.V3().GetUsers(LargeQuery)
 	if err == nil {
@@ -240,22 +403,92 @@ func (c *Fetcher) fetchUsers(session *SessionExt, _ *BBSClient, entry *models.CF
 	return err
 }
 
+func (c *Fetcher) fetchRoles(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
+	roles, _, _, err := session.V3().GetRoles(LargeQuery)
+	if err == nil {
+		loadIndex(entry.Roles, roles, func(r resources.Role) string { return r.GUID })
+	}
+	return err
+}
+
 // fetchEvents -
-//  1. create query param "created_ats[gt]=(now - 15min)". There is no point scrapping more
-//     data since the event metric will filter out events older than last scrap.
+//  1. create query param "created_ats[gt]=(min(now, last check) - lookback)". There is no point
+//     scrapping more data since the event metric will filter out events older than last scrap.
+//     The last check is older than now after a restart resuming from a persisted state
+//  2. sort by ascending creation time, events created while walking through the pages are
+//     appended to the last one instead of shifting already fetched events to the next one
+//  3. the Cloud Controller does not filter audit events on target types, filter them here
 func (c *Fetcher) fetchEvents(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
 	// 1.
+	lookback := c.cfConfig.EventsLookback
+	if lookback <= 0 {
+		lookback = DefaultEventsLookback
+	}
 	location, _ := time.LoadLocation("UTC")
-	since := time.Now().Add(-1 * 15 * time.Minute)
+	since := time.Now()
+	if !c.cfConfig.EventsSince.IsZero() && c.cfConfig.EventsSince.Before(since) {
+		since = c.cfConfig.EventsSince
+	}
+	since = since.Add(-1 * lookback)
 	newTime := since.In(location).Format("2006-01-02T15:04:05Z")
 	recent := ccv3.Query{
 		Key:    "created_ats[gt]",
 		Values: []string{newTime},
 	}
 
-	events, err := session.GetEvents(LargeQuery, SortDesc, recent)
+	// 2.
+	query := []ccv3.Query{LargeQuery, SortAsc, recent}
+	if types := EventTypesQuery(c.cfConfig.EventTypes); len(types.Values) != 0 {
+		query = append(query, types)
+	}
+
+	events, err := session.GetEvents(query...)
+	if err != nil {
+		return err
+	}
+
+	// 3.
+	targetTypes := normalizeEventTypes(c.cfConfig.EventTargetTypes)
+	for _, event := range events {
+		if len(targetTypes) != 0 && !slices.Contains(targetTypes, strings.ToLower(event.Target.Type)) {
+			continue
+		}
+		entry.Events[event.GUID] = event
+	}
+	return nil
+}
+
+// fetchAppUsageEvents -
+//  1. resume after the last event consumed by the usage events collector
+//  2. the cursor is rejected once the Cloud Controller purged its event, start over from the
+//     oldest event, the collector does not accrue usage it already accounted for
+func (c *Fetcher) fetchAppUsageEvents(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
+	// 1.
+	events, err := session.GetAppUsageEvents(c.cfConfig.AppUsageAfter)
+	// 2.
+	if err != nil && c.cfConfig.AppUsageAfter != "" {
+		log.WithError(err).Warnf("unable to resume app usage events after '%s', starting over from the oldest one", c.cfConfig.AppUsageAfter)
+		events, err = session.GetAppUsageEvents("")
+	}
+	if err == nil {
+		entry.AppUsageEvents = events
+	}
+	return err
+}
+
+// fetchServiceUsageEvents -
+//  1. resume after the last event consumed by the usage events collector
+//  2. start over from the oldest event when the cursor is rejected, see fetchAppUsageEvents
+func (c *Fetcher) fetchServiceUsageEvents(session *SessionExt, _ *BBSClient, entry *models.CFObjects) error {
+	// 1.
+	events, err := session.GetServiceUsageEvents(c.cfConfig.ServiceUsageAfter)
+	// 2.
+	if err != nil && c.cfConfig.ServiceUsageAfter != "" {
+		log.WithError(err).Warnf("unable to resume service usage events after '%s', starting over from the oldest one", c.cfConfig.ServiceUsageAfter)
+		events, err = session.GetServiceUsageEvents("")
+	}
 	if err == nil {
-		loadIndex(entry.Events, events, func(r models.Event) string { return r.GUID })
+		entry.ServiceUsageEvents = events
 	}
 	return err
 }
@@ -36,6 +36,13 @@ var _ = ginkgo.Describe("Fetcher", func() {
 			for w := range fetcher.worker.list {
 				jobs = append(jobs, w.name)
 			}
+
+			fetcher.workInitDependent(models.NewCFObjects())
+
+			close(fetcher.worker.list)
+			for w := range fetcher.worker.list {
+				jobs = append(jobs, w.name)
+			}
 		})
 
 		ginkgo.When("default filters are set", func() {
@@ -50,6 +57,7 @@ var _ = ginkgo.Describe("Fetcher", func() {
 					"applications",
 					"droplets",
 					"domains",
+					"feature_flags",
 					"process",
 					"routes",
 					"route_services",
@@ -63,6 +71,7 @@ var _ = ginkgo.Describe("Fetcher", func() {
 					"service_bindings",
 					"service_route_bindings",
 					"segments",
+					"segment_placements",
 					"actual_lrps",
 				}
 			})
@@ -82,7 +91,10 @@ var _ = ginkgo.Describe("Fetcher", func() {
 					"space_quotas",
 					"applications",
 					"droplets",
+					"deployments",
+					"builds",
 					"domains",
+					"feature_flags",
 					"process",
 					"routes",
 					"route_services",
@@ -97,9 +109,13 @@ var _ = ginkgo.Describe("Fetcher", func() {
 					"service_bindings",
 					"service_route_bindings",
 					"segments",
+					"segment_placements",
 					"users",
+					"roles",
 					"events",
 					"actual_lrps",
+					"app_usage_events",
+					"service_usage_events",
 				}
 			})
 			ginkgo.It("plans all jobs", func() {
@@ -130,7 +146,7 @@ var _ = ginkgo.Describe("Fetcher", func() {
 		ginkgo.When("buildpack filter is set", func() {
 			ginkgo.BeforeEach(func() {
 				active = []string{filters.Buildpacks}
-				expected = []string{"info", "buildpacks"}
+				expected = []string{"info", "applications", "droplets", "buildpacks"}
 			})
 			ginkgo.It("plans only specific jobs", func() {
 				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
@@ -150,7 +166,7 @@ var _ = ginkgo.Describe("Fetcher", func() {
 		ginkgo.When("isolationsegments filter is set", func() {
 			ginkgo.BeforeEach(func() {
 				active = []string{filters.IsolationSegments}
-				expected = []string{"info", "segments"}
+				expected = []string{"info", "organizations", "spaces", "applications", "process", "segments", "segment_placements"}
 			})
 			ginkgo.It("plans only specific jobs", func() {
 				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
@@ -160,7 +176,7 @@ var _ = ginkgo.Describe("Fetcher", func() {
 		ginkgo.When("routes filter is set", func() {
 			ginkgo.BeforeEach(func() {
 				active = []string{filters.Routes}
-				expected = []string{"info", "routes", "route_services"}
+				expected = []string{"info", "organizations", "spaces", "applications", "domains", "routes", "route_services"}
 			})
 			ginkgo.It("plans only specific jobs", func() {
 				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
@@ -187,10 +203,50 @@ var _ = ginkgo.Describe("Fetcher", func() {
 			})
 		})
 
+		ginkgo.When("securityposture filter is set", func() {
+			ginkgo.BeforeEach(func() {
+				active = []string{filters.SecurityPosture}
+				expected = []string{
+					"info",
+					"organizations",
+					"org_quotas",
+					"spaces",
+					"space_quotas",
+					"applications",
+					"security_groups",
+					"stacks",
+					"service_bindings",
+				}
+			})
+			ginkgo.It("plans only specific jobs", func() {
+				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
+			})
+		})
+
 		ginkgo.When("serviceinstances filter is set", func() {
 			ginkgo.BeforeEach(func() {
 				active = []string{filters.ServiceInstances}
-				expected = []string{"info", "service_instances"}
+				expected = []string{"info", "spaces", "service_offerings", "service_instances", "service_plans"}
+			})
+			ginkgo.It("plans only specific jobs", func() {
+				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
+			})
+		})
+
+		ginkgo.When("servicebrokers filter is set", func() {
+			ginkgo.BeforeEach(func() {
+				active = []string{filters.ServiceBrokers}
+				expected = []string{"info", "service_brokers", "service_offerings", "service_instances", "service_plans"}
+			})
+			ginkgo.It("plans only specific jobs", func() {
+				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
+			})
+		})
+
+		ginkgo.When("serviceplans filter is set", func() {
+			ginkgo.BeforeEach(func() {
+				active = []string{filters.ServicePlans}
+				expected = []string{"info", "service_instances", "service_plans"}
 			})
 			ginkgo.It("plans only specific jobs", func() {
 				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
@@ -207,10 +263,40 @@ var _ = ginkgo.Describe("Fetcher", func() {
 			})
 		})
 
+		ginkgo.When("feature flags filter is set", func() {
+			ginkgo.BeforeEach(func() {
+				active = []string{filters.FeatureFlags}
+				expected = []string{"info", "feature_flags"}
+			})
+			ginkgo.It("plans only specific jobs", func() {
+				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
+			})
+		})
+
+		ginkgo.When("roles filter is set", func() {
+			ginkgo.BeforeEach(func() {
+				active = []string{filters.Roles}
+				expected = []string{"info", "organizations", "spaces", "users", "roles"}
+			})
+			ginkgo.It("plans only specific jobs", func() {
+				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
+			})
+		})
+
+		ginkgo.When("usage events filter is set", func() {
+			ginkgo.BeforeEach(func() {
+				active = []string{filters.UsageEvents}
+				expected = []string{"info", "app_usage_events", "service_usage_events"}
+			})
+			ginkgo.It("plans only specific jobs", func() {
+				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
+			})
+		})
+
 		ginkgo.When("stacks filter is set", func() {
 			ginkgo.BeforeEach(func() {
 				active = []string{filters.Stacks}
-				expected = []string{"info", "stacks"}
+				expected = []string{"info", "applications", "process", "stacks"}
 			})
 			ginkgo.It("plans only specific jobs", func() {
 				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
@@ -220,7 +306,7 @@ var _ = ginkgo.Describe("Fetcher", func() {
 		ginkgo.When("applications filter is set", func() {
 			ginkgo.BeforeEach(func() {
 				active = []string{filters.Applications}
-				expected = []string{"info", "organizations", "spaces", "applications", "process"}
+				expected = []string{"info", "organizations", "spaces", "applications", "process", "stacks"}
 			})
 			ginkgo.It("plans only specific jobs", func() {
 				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
@@ -240,7 +326,27 @@ var _ = ginkgo.Describe("Fetcher", func() {
 		ginkgo.When("droplets filter is set", func() {
 			ginkgo.BeforeEach(func() {
 				active = []string{filters.Droplets}
-				expected = []string{"info", "droplets"}
+				expected = []string{"info", "applications", "droplets", "buildpacks"}
+			})
+			ginkgo.It("plans only specific jobs", func() {
+				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
+			})
+		})
+
+		ginkgo.When("deployments filter is set", func() {
+			ginkgo.BeforeEach(func() {
+				active = []string{filters.Deployments}
+				expected = []string{"info", "applications", "deployments"}
+			})
+			ginkgo.It("plans only specific jobs", func() {
+				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
+			})
+		})
+
+		ginkgo.When("builds filter is set", func() {
+			ginkgo.BeforeEach(func() {
+				active = []string{filters.Builds}
+				expected = []string{"info", "organizations", "spaces", "applications", "builds"}
 			})
 			ginkgo.It("plans only specific jobs", func() {
 				gomega.Ω(jobs).Should(gomega.ConsistOf(expected))
@@ -259,6 +365,50 @@ var _ = ginkgo.Describe("Fetcher", func() {
 
 	})
 
+	ginkgo.Context("dependent jobs are planned according to fetched objects", func() {
+		ginkgo.It("spreads the shareable service instances over chunks", func() {
+			f, err := filters.NewFilter(filters.ServiceInstances)
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			fetcher := NewFetcher(10, &CFConfig{}, &BBSConfig{}, f)
+
+			entry := models.NewCFObjects()
+			entry.ServiceOfferings["offering1-guid"] = models.ServiceOffering{ServiceOffering: resources.ServiceOffering{GUID: "offering1-guid", AllowsInstanceSharing: true}}
+			entry.ServicePlans["plan1-guid"] = resources.ServicePlan{GUID: "plan1-guid", ServiceOfferingGUID: "offering1-guid"}
+			for i := 0; i < DependentChunkSize+1; i++ {
+				guid := fmt.Sprintf("instance%d-guid", i)
+				entry.ServiceInstances[guid] = resources.ServiceInstance{GUID: guid, Type: resources.ManagedServiceInstance, ServicePlanGUID: "plan1-guid"}
+			}
+			fetcher.workInitDependent(entry)
+
+			close(fetcher.worker.list)
+			jobs := []string{}
+			for w := range fetcher.worker.list {
+				jobs = append(jobs, w.name)
+			}
+			gomega.Ω(jobs).Should(gomega.Equal([]string{"service_instance_shares", "service_instance_shares"}))
+		})
+
+		ginkgo.It("spreads the spaces over chunks to ask their ssh features", func() {
+			f, err := filters.NewFilter(filters.SecurityPosture)
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			fetcher := NewFetcher(10, &CFConfig{}, &BBSConfig{}, f)
+
+			entry := models.NewCFObjects()
+			for i := 0; i < DependentChunkSize+1; i++ {
+				guid := fmt.Sprintf("space%d-guid", i)
+				entry.Spaces[guid] = resources.Space{GUID: guid}
+			}
+			fetcher.workInitDependent(entry)
+
+			close(fetcher.worker.list)
+			jobs := []string{}
+			for w := range fetcher.worker.list {
+				jobs = append(jobs, w.name)
+			}
+			gomega.Ω(jobs).Should(gomega.Equal([]string{"ssh_enabled", "ssh_enabled"}))
+		})
+	})
+
 	ginkgo.Context("disabling filters during a scrape", func() {
 		ginkgo.It("does not mutate the original filter used for future scrapes", func() {
 			filter, err := filters.NewFilter()
@@ -77,6 +77,221 @@ func (s SessionExt) GetApplications() ([]models.Application, error) {
 	return res, err
 }
 
+func (s SessionExt) GetDroplets() ([]models.Droplet, error) {
+	res := []models.Droplet{}
+	_, _, err := s.V3().MakeListRequest(ccv3.RequestParams{
+		RequestName:  "GetDroplets",
+		Query:        []ccv3.Query{LargeQuery},
+		ResponseBody: models.Droplet{},
+		AppendToList: func(item interface{}) error {
+			res = append(res, item.(models.Droplet))
+			return nil
+		},
+	})
+	return res, err
+}
+
+// rawListPage is a single page of a v3 list endpoint
+type rawListPage[T any] struct {
+	Pagination struct {
+		Next struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []T `json:"resources"`
+}
+
+// getRawList fetches every page of a v3 list endpoint that is not known by
+// the cf cli request routes
+func getRawList[T any](s SessionExt, path string, query ...ccv3.Query) ([]T, error) {
+	res := []T{}
+	url := fmt.Sprintf("%s%s?%s", s.V3().CloudControllerURL, path, ccv3.FormatQueryParameters(query).Encode())
+	for url != "" {
+		body, httpres, err := s.V3().MakeRequestSendReceiveRaw("GET", url, http.Header{}, nil)
+		if err != nil {
+			return res, err
+		}
+		if err := httpres.Body.Close(); err != nil {
+			log.Errorf("error closing response body: %s", err)
+		}
+		if httpres.StatusCode != http.StatusOK {
+			return res, fmt.Errorf("unexpected status code %d on request %s", httpres.StatusCode, url)
+		}
+
+		page := rawListPage[T]{}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return res, err
+		}
+		res = append(res, page.Resources...)
+
+		url = page.Pagination.Next.Href
+	}
+	return res, nil
+}
+
+func (s SessionExt) GetBuilds() ([]models.Build, error) {
+	return getRawList[models.Build](s, "/v3/builds", LargeQuery)
+}
+
+// usageEventsQuery lists usage events from the oldest one, or after the given
+// event when resuming
+func usageEventsQuery(afterGUID string) []ccv3.Query {
+	query := []ccv3.Query{LargeQuery, SortAsc}
+	if afterGUID != "" {
+		query = append(query, ccv3.Query{
+			Key:    "after_guid",
+			Values: []string{afterGUID},
+		})
+	}
+	return query
+}
+
+func (s SessionExt) GetAppUsageEvents(afterGUID string) ([]models.AppUsageEvent, error) {
+	return getRawList[models.AppUsageEvent](s, "/v3/app_usage_events", usageEventsQuery(afterGUID)...)
+}
+
+func (s SessionExt) GetServiceUsageEvents(afterGUID string) ([]models.ServiceUsageEvent, error) {
+	return getRawList[models.ServiceUsageEvent](s, "/v3/service_usage_events", usageEventsQuery(afterGUID)...)
+}
+
+func (s SessionExt) GetRoutes() ([]models.Route, error) {
+	res := []models.Route{}
+	_, _, err := s.V3().MakeListRequest(ccv3.RequestParams{
+		RequestName:  "GetRoutes",
+		Query:        []ccv3.Query{LargeQuery},
+		ResponseBody: models.Route{},
+		AppendToList: func(item interface{}) error {
+			res = append(res, item.(models.Route))
+			return nil
+		},
+	})
+	return res, err
+}
+
+func (s SessionExt) GetServiceOfferings() ([]models.ServiceOffering, error) {
+	res := []models.ServiceOffering{}
+	_, _, err := s.V3().MakeListRequest(ccv3.RequestParams{
+		RequestName:  "GetServiceOfferings",
+		Query:        []ccv3.Query{LargeQuery},
+		ResponseBody: models.ServiceOffering{},
+		AppendToList: func(item interface{}) error {
+			res = append(res, item.(models.ServiceOffering))
+			return nil
+		},
+	})
+	return res, err
+}
+
+func (s SessionExt) GetBuildpacks() ([]models.Buildpack, error) {
+	res := []models.Buildpack{}
+	_, _, err := s.V3().MakeListRequest(ccv3.RequestParams{
+		RequestName:  "GetBuildpacks",
+		Query:        []ccv3.Query{LargeQuery},
+		ResponseBody: models.Buildpack{},
+		AppendToList: func(item interface{}) error {
+			res = append(res, item.(models.Buildpack))
+			return nil
+		},
+	})
+	return res, err
+}
+
+func (s SessionExt) GetFeatureFlags() ([]models.FeatureFlag, error) {
+	res := []models.FeatureFlag{}
+	_, _, err := s.V3().MakeListRequest(ccv3.RequestParams{
+		RequestName:  "GetFeatureFlags",
+		Query:        []ccv3.Query{LargeQuery},
+		ResponseBody: models.FeatureFlag{},
+		AppendToList: func(item interface{}) error {
+			res = append(res, item.(models.FeatureFlag))
+			return nil
+		},
+	})
+	return res, err
+}
+
+func (s SessionExt) GetStacks() ([]models.Stack, error) {
+	res := []models.Stack{}
+	_, _, err := s.V3().MakeListRequest(ccv3.RequestParams{
+		RequestName:  "GetStacks",
+		Query:        []ccv3.Query{LargeQuery},
+		ResponseBody: models.Stack{},
+		AppendToList: func(item interface{}) error {
+			res = append(res, item.(models.Stack))
+			return nil
+		},
+	})
+	return res, err
+}
+
+func (s SessionExt) GetServiceCredentialBindings() ([]models.ServiceCredentialBinding, error) {
+	res := []models.ServiceCredentialBinding{}
+	_, _, err := s.V3().MakeListRequest(ccv3.RequestParams{
+		RequestName:  "GetServiceCredentialBindings",
+		Query:        []ccv3.Query{LargeQuery},
+		ResponseBody: models.ServiceCredentialBinding{},
+		AppendToList: func(item interface{}) error {
+			res = append(res, item.(models.ServiceCredentialBinding))
+			return nil
+		},
+	})
+	return res, err
+}
+
+// GetServiceInstanceShares returns the spaces the given service instance is
+// shared to
+func (s SessionExt) GetServiceInstanceShares(guid string) ([]models.ServiceInstanceShare, error) {
+	res := []models.ServiceInstanceShare{}
+	spaces, _, err := s.V3().GetServiceInstanceSharedSpaces(guid)
+	if err != nil {
+		return res, err
+	}
+	for _, space := range spaces {
+		res = append(res, models.ServiceInstanceShare{
+			ServiceInstanceGUID: guid,
+			SpaceGUID:           space.SpaceGUID,
+			SpaceName:           space.SpaceName,
+			OrganizationName:    space.OrganizationName,
+		})
+	}
+	return res, nil
+}
+
+// GetIsolationSegmentRelationship returns the guids of the organizations or
+// spaces related to the given isolation segment, the ccv3 client only knows
+// the endpoint listing whole organizations
+func (s SessionExt) GetIsolationSegmentRelationship(guid string, relationship string) ([]string, error) {
+	res := []string{}
+	body, httpres, err := s.V3().MakeRequestSendReceiveRaw(
+		"GET",
+		fmt.Sprintf("%s/v3/isolation_segments/%s/relationships/%s", s.V3().CloudControllerURL, guid, relationship),
+		http.Header{},
+		nil,
+	)
+	if err != nil {
+		return res, err
+	}
+	if err := httpres.Body.Close(); err != nil {
+		log.Errorf("error closing response body: %s", err)
+	}
+	if httpres.StatusCode != http.StatusOK {
+		return res, fmt.Errorf("unexpected status code %d on isolation segment '%s' %s", httpres.StatusCode, guid, relationship)
+	}
+
+	relationships := struct {
+		Data []struct {
+			GUID string `json:"guid"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &relationships); err != nil {
+		return res, err
+	}
+	for _, rel := range relationships.Data {
+		res = append(res, rel.GUID)
+	}
+	return res, nil
+}
+
 func TaskStatesQuery(states []string) ccv3.Query {
 	normalized := normalizeTaskStates(states)
 	return ccv3.Query{
@@ -103,6 +318,30 @@ func normalizeTaskStates(states []string) []string {
 	return normalized
 }
 
+// EventTypesFilter is the audit events types filter, unknown to the ccv3 client
+const EventTypesFilter ccv3.QueryKey = "types"
+
+// EventTypesQuery filters audit events on the given types, the Cloud
+// Controller returns all types when none is given
+func EventTypesQuery(types []string) ccv3.Query {
+	return ccv3.Query{
+		Key:    EventTypesFilter,
+		Values: normalizeEventTypes(types),
+	}
+}
+
+func normalizeEventTypes(types []string) []string {
+	normalized := make([]string, 0, len(types))
+	for _, t := range types {
+		trimmed := strings.TrimSpace(t)
+		if trimmed == "" {
+			continue
+		}
+		normalized = append(normalized, strings.ToLower(trimmed))
+	}
+	return normalized
+}
+
 func (s SessionExt) GetTasks(states []string) ([]models.Task, error) {
 	res := []models.Task{}
 	_, _, err := s.V3().MakeListRequest(ccv3.RequestParams{
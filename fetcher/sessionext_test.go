@@ -7,6 +7,7 @@ import (
 	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
 	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
 	"code.cloudfoundry.org/cli/v8/resources"
+	"code.cloudfoundry.org/cli/v8/types"
 
 	"github.com/onsi/gomega/ghttp"
 
@@ -121,6 +122,474 @@ var _ = ginkgo.Describe("Extensions", func() {
 		})
 	})
 
+	ginkgo.Context("fetching droplets", func() {
+		ginkgo.It("no error occurs", func() {
+			created := time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/droplets", "per_page=5000"),
+					ghttp.RespondWith(http.StatusOK, serializeList(models.Droplet{
+						GUID:  "droplet1-guid",
+						State: constant.DropletStaged,
+						Stack: "cflinuxfs4",
+						Buildpacks: []resources.DropletBuildpack{
+							{Name: "go_buildpack", BuildpackName: "go", Version: "1.10.0"},
+						},
+						Relationships: resources.Relationships{
+							constant.RelationshipTypeApplication: resources.Relationship{GUID: "app1-guid"},
+						},
+						CreatedAt: created,
+					})),
+				),
+			)
+			objs, err := target.GetDroplets()
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(objs).Should(gomega.HaveLen(1))
+			gomega.Ω(objs[0].GUID).Should(gomega.Equal("droplet1-guid"))
+			gomega.Ω(objs[0].State).Should(gomega.Equal(constant.DropletStaged))
+			gomega.Ω(objs[0].Buildpacks).Should(gomega.HaveLen(1))
+			gomega.Ω(objs[0].Buildpacks[0].Version).Should(gomega.Equal("1.10.0"))
+			gomega.Ω(objs[0].Relationships[constant.RelationshipTypeApplication].GUID).Should(gomega.Equal("app1-guid"))
+			gomega.Ω(objs[0].CreatedAt).Should(gomega.BeTemporally("==", created))
+		})
+	})
+
+	ginkgo.Context("fetching builds", func() {
+		ginkgo.It("follows pagination", func() {
+			created := time.Now().Add(-1 * time.Hour).UTC().Truncate(time.Second)
+			firstPage := &ccv3.PaginatedResources{
+				ResourcesBytes: []byte(serialize([]models.Build{{
+					GUID:      "build1-guid",
+					State:     constant.BuildStaged,
+					CreatedAt: created,
+					UpdatedAt: created.Add(time.Minute),
+				}})),
+			}
+			firstPage.Pagination.Next.HREF = server.URL() + "/v3/builds?page=2&per_page=5000"
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/builds", "per_page=5000"),
+					ghttp.RespondWith(http.StatusOK, serialize(firstPage)),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/oauth/token"),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{"access_token": "%s", "refresh_token": "value"}`, fakeToken)),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/builds", "page=2&per_page=5000"),
+					ghttp.RespondWith(http.StatusOK, serializeList(models.Build{
+						GUID:  "build2-guid",
+						State: constant.BuildFailed,
+						Error: "StagingError - Staging error: staging failed",
+						Relationships: resources.Relationships{
+							constant.RelationshipTypeApplication: resources.Relationship{GUID: "app1-guid"},
+						},
+					})),
+				),
+			)
+			objs, err := target.GetBuilds()
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(objs).Should(gomega.HaveLen(2))
+			gomega.Ω(objs[0].GUID).Should(gomega.Equal("build1-guid"))
+			gomega.Ω(objs[0].UpdatedAt.Sub(objs[0].CreatedAt)).Should(gomega.Equal(time.Minute))
+			gomega.Ω(objs[1].State).Should(gomega.Equal(constant.BuildFailed))
+			gomega.Ω(objs[1].Error).Should(gomega.HavePrefix("StagingError"))
+			gomega.Ω(objs[1].Relationships[constant.RelationshipTypeApplication].GUID).Should(gomega.Equal("app1-guid"))
+		})
+	})
+
+	ginkgo.Context("fetching usage events", func() {
+		ginkgo.It("resumes app usage events after the cursor", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/app_usage_events", "after_guid=event0-guid&order_by=created_at&per_page=5000"),
+					ghttp.RespondWith(http.StatusOK, serialize(&ccv3.PaginatedResources{
+						ResourcesBytes: []byte(`[{
+							"guid": "event1-guid",
+							"created_at": "2024-01-02T03:04:05Z",
+							"state": {"current": "STARTED", "previous": "STOPPED"},
+							"app": {"guid": "app1-guid", "name": "app1"},
+							"process": {"guid": "process1-guid", "type": "web"},
+							"task": {"guid": null, "name": null},
+							"space": {"guid": "space1-guid", "name": "space1"},
+							"organization": {"guid": "org1-guid"},
+							"memory_in_mb_per_instance": {"current": 512, "previous": null},
+							"instance_count": {"current": 2, "previous": null}
+						}]`),
+					})),
+				),
+			)
+			objs, err := target.GetAppUsageEvents("event0-guid")
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(objs).Should(gomega.HaveLen(1))
+			gomega.Ω(objs[0].GUID).Should(gomega.Equal("event1-guid"))
+			gomega.Ω(objs[0].State.Current).Should(gomega.Equal("STARTED"))
+			gomega.Ω(objs[0].Process.GUID).Should(gomega.Equal("process1-guid"))
+			gomega.Ω(objs[0].Task.GUID).Should(gomega.BeEmpty())
+			gomega.Ω(objs[0].Space.Name).Should(gomega.Equal("space1"))
+			gomega.Ω(objs[0].Org.GUID).Should(gomega.Equal("org1-guid"))
+			gomega.Ω(objs[0].MemoryInMBPerInstance.Current).Should(gomega.Equal(512))
+			gomega.Ω(objs[0].InstanceCount.Current).Should(gomega.Equal(2))
+		})
+
+		ginkgo.It("lists service usage events from the oldest one", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/service_usage_events", "order_by=created_at&per_page=5000"),
+					ghttp.RespondWith(http.StatusOK, serialize(&ccv3.PaginatedResources{
+						ResourcesBytes: []byte(`[{
+							"guid": "event1-guid",
+							"created_at": "2024-01-02T03:04:05Z",
+							"state": "CREATED",
+							"space": {"guid": "space1-guid", "name": "space1"},
+							"organization": {"guid": "org1-guid"},
+							"service_instance": {"guid": "instance1-guid", "name": "db", "type": "managed_service_instance"},
+							"service_plan": {"guid": "plan1-guid", "name": "small"},
+							"service_offering": {"guid": "offering1-guid", "name": "postgres"}
+						}]`),
+					})),
+				),
+			)
+			objs, err := target.GetServiceUsageEvents("")
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(objs).Should(gomega.HaveLen(1))
+			gomega.Ω(objs[0].State).Should(gomega.Equal("CREATED"))
+			gomega.Ω(objs[0].ServiceInstance.Type).Should(gomega.Equal("managed_service_instance"))
+			gomega.Ω(objs[0].ServicePlan.Name).Should(gomega.Equal("small"))
+			gomega.Ω(objs[0].ServiceOffering.Name).Should(gomega.Equal("postgres"))
+			gomega.Ω(objs[0].CreatedAt.Unix()).Should(gomega.Equal(int64(1704164645)))
+		})
+	})
+
+	ginkgo.Context("fetching routes", func() {
+		ginkgo.It("no error occurs", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/routes", "per_page=5000"),
+					ghttp.RespondWith(http.StatusOK, serialize(&ccv3.PaginatedResources{
+						ResourcesBytes: []byte(`[{
+							"guid": "route1-guid",
+							"host": "app1",
+							"path": "/api",
+							"url": "app1.example.com/api",
+							"relationships": {
+								"space": {"data": {"guid": "space1-guid"}},
+								"domain": {"data": {"guid": "domain1-guid"}}
+							},
+							"destinations": [{
+								"guid": "destination1-guid",
+								"app": {"guid": "app1-guid", "process": {"type": "web"}},
+								"weight": 80,
+								"port": 8080,
+								"protocol": "http2"
+							}]
+						}]`),
+					})),
+				),
+			)
+			objs, err := target.GetRoutes()
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(objs).Should(gomega.HaveLen(1))
+			gomega.Ω(objs[0].GUID).Should(gomega.Equal("route1-guid"))
+			gomega.Ω(objs[0].SpaceGUID).Should(gomega.Equal("space1-guid"))
+			gomega.Ω(objs[0].DomainGUID).Should(gomega.Equal("domain1-guid"))
+			gomega.Ω(objs[0].Destinations).Should(gomega.HaveLen(1))
+			gomega.Ω(objs[0].Destinations[0].App.GUID).Should(gomega.Equal("app1-guid"))
+			gomega.Ω(objs[0].Destinations[0].App.Process.Type).Should(gomega.Equal("web"))
+			gomega.Ω(objs[0].Destinations[0].Weight).Should(gomega.Equal(types.NullInt{IsSet: true, Value: 80}))
+			gomega.Ω(objs[0].Destinations[0].Port).Should(gomega.Equal(8080))
+			gomega.Ω(objs[0].Destinations[0].Protocol).Should(gomega.Equal("http2"))
+		})
+	})
+
+	ginkgo.Context("fetching service offerings", func() {
+		ginkgo.It("no error occurs", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/service_offerings", "per_page=5000"),
+					ghttp.RespondWith(http.StatusOK, serialize(&ccv3.PaginatedResources{
+						ResourcesBytes: []byte(`[{
+							"guid": "offering1-guid",
+							"name": "postgres",
+							"available": true,
+							"shareable": true,
+							"tags": ["sql", "relational"],
+							"broker_catalog": {"features": {"bindable": true}},
+							"relationships": {
+								"service_broker": {"data": {"guid": "broker1-guid"}}
+							}
+						}]`),
+					})),
+				),
+			)
+			objs, err := target.GetServiceOfferings()
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(objs).Should(gomega.HaveLen(1))
+			gomega.Ω(objs[0].GUID).Should(gomega.Equal("offering1-guid"))
+			gomega.Ω(objs[0].Name).Should(gomega.Equal("postgres"))
+			gomega.Ω(objs[0].ServiceBrokerGUID).Should(gomega.Equal("broker1-guid"))
+			gomega.Ω(objs[0].AllowsInstanceSharing).Should(gomega.BeTrue())
+			gomega.Ω(objs[0].Tags.Value).Should(gomega.Equal([]string{"sql", "relational"}))
+			gomega.Ω(objs[0].Available).Should(gomega.BeTrue())
+			gomega.Ω(objs[0].BrokerCatalog.Features.Bindable).Should(gomega.BeTrue())
+		})
+	})
+
+	ginkgo.Context("fetching feature flags", func() {
+		ginkgo.It("no error occurs", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/feature_flags", "per_page=5000"),
+					ghttp.RespondWith(http.StatusOK, serialize(&ccv3.PaginatedResources{
+						ResourcesBytes: []byte(`[
+							{"name": "diego_docker", "enabled": true, "updated_at": "2024-01-02T03:04:05Z", "custom_error_message": null},
+							{"name": "user_org_creation", "enabled": false, "updated_at": null, "custom_error_message": null}
+						]`),
+					})),
+				),
+			)
+			objs, err := target.GetFeatureFlags()
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(objs).Should(gomega.HaveLen(2))
+			gomega.Ω(objs[0].Name).Should(gomega.Equal("diego_docker"))
+			gomega.Ω(objs[0].Enabled).Should(gomega.BeTrue())
+			gomega.Ω(objs[0].UpdatedAt.Unix()).Should(gomega.Equal(int64(1704164645)))
+			gomega.Ω(objs[1].Enabled).Should(gomega.BeFalse())
+			gomega.Ω(objs[1].UpdatedAt.IsZero()).Should(gomega.BeTrue())
+		})
+	})
+
+	ginkgo.Context("fetching stacks", func() {
+		ginkgo.It("no error occurs", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/stacks", "per_page=5000"),
+					ghttp.RespondWith(http.StatusOK, serialize(&ccv3.PaginatedResources{
+						ResourcesBytes: []byte(`[
+							{"guid": "stack1-guid", "name": "cflinuxfs3", "state": "DEPRECATED", "default": false},
+							{"guid": "stack2-guid", "name": "cflinuxfs4", "state": "ACTIVE", "default": true}
+						]`),
+					})),
+				),
+			)
+			objs, err := target.GetStacks()
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(objs).Should(gomega.HaveLen(2))
+			gomega.Ω(objs[0].Name).Should(gomega.Equal("cflinuxfs3"))
+			gomega.Ω(objs[0].State).Should(gomega.Equal("DEPRECATED"))
+			gomega.Ω(objs[0].Default).Should(gomega.BeFalse())
+			gomega.Ω(objs[1].GUID).Should(gomega.Equal("stack2-guid"))
+			gomega.Ω(objs[1].Default).Should(gomega.BeTrue())
+		})
+	})
+
+	ginkgo.Context("fetching buildpacks", func() {
+		ginkgo.It("no error occurs", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/buildpacks", "per_page=5000"),
+					ghttp.RespondWith(http.StatusOK, serialize(&ccv3.PaginatedResources{
+						ResourcesBytes: []byte(`[{
+							"guid": "buildpack1-guid",
+							"name": "go_buildpack",
+							"stack": "cflinuxfs4",
+							"state": "READY",
+							"lifecycle": "buildpack",
+							"filename": "go_buildpack-cflinuxfs4-v1.10.0.zip",
+							"position": 3,
+							"enabled": true,
+							"locked": false,
+							"created_at": "2024-01-02T03:04:05Z",
+							"updated_at": "2024-02-03T04:05:06Z"
+						}]`),
+					})),
+				),
+			)
+			objs, err := target.GetBuildpacks()
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(objs).Should(gomega.HaveLen(1))
+			gomega.Ω(objs[0].GUID).Should(gomega.Equal("buildpack1-guid"))
+			gomega.Ω(objs[0].State).Should(gomega.Equal("READY"))
+			gomega.Ω(objs[0].Lifecycle).Should(gomega.Equal("buildpack"))
+			gomega.Ω(objs[0].Position.Value).Should(gomega.Equal(3))
+			gomega.Ω(objs[0].Enabled.Value).Should(gomega.BeTrue())
+			gomega.Ω(objs[0].Locked.Value).Should(gomega.BeFalse())
+			gomega.Ω(objs[0].CreatedAt.Unix()).Should(gomega.Equal(int64(1704164645)))
+			gomega.Ω(objs[0].UpdatedAt.Unix()).Should(gomega.Equal(int64(1706933106)))
+		})
+	})
+
+	ginkgo.Context("fetching service credential bindings", func() {
+		ginkgo.It("no error occurs", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/service_credential_bindings", "per_page=5000"),
+					ghttp.RespondWith(http.StatusOK, serialize(&ccv3.PaginatedResources{
+						ResourcesBytes: []byte(`[{
+							"guid": "binding1-guid",
+							"name": "my-key",
+							"type": "key",
+							"created_at": "2024-01-02T03:04:05Z",
+							"updated_at": "2024-02-03T04:05:06Z",
+							"last_operation": {"type": "create", "state": "succeeded"},
+							"relationships": {
+								"service_instance": {"data": {"guid": "instance1-guid"}}
+							}
+						}]`),
+					})),
+				),
+			)
+			objs, err := target.GetServiceCredentialBindings()
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(objs).Should(gomega.HaveLen(1))
+			gomega.Ω(objs[0].GUID).Should(gomega.Equal("binding1-guid"))
+			gomega.Ω(objs[0].Name).Should(gomega.Equal("my-key"))
+			gomega.Ω(objs[0].Type).Should(gomega.Equal(resources.KeyBinding))
+			gomega.Ω(objs[0].ServiceInstanceGUID).Should(gomega.Equal("instance1-guid"))
+			gomega.Ω(objs[0].LastOperation.State).Should(gomega.Equal(resources.OperationSucceeded))
+			gomega.Ω(objs[0].CreatedAt).Should(gomega.Equal("2024-01-02T03:04:05Z"))
+			gomega.Ω(objs[0].UpdatedAt).Should(gomega.Equal("2024-02-03T04:05:06Z"))
+		})
+	})
+
+	ginkgo.Context("fetching service instance shares", func() {
+		ginkgo.It("no error occurs", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/service_instances/instance1-guid/relationships/shared_spaces"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"data": [{"guid": "space2-guid"}],
+						"included": {
+							"spaces": [{"guid": "space2-guid", "name": "space2", "relationships": {"organization": {"data": {"guid": "org2-guid"}}}}],
+							"organizations": [{"guid": "org2-guid", "name": "org2"}]
+						}
+					}`),
+				),
+			)
+			objs, err := target.GetServiceInstanceShares("instance1-guid")
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(objs).Should(gomega.HaveLen(1))
+			gomega.Ω(objs[0].ServiceInstanceGUID).Should(gomega.Equal("instance1-guid"))
+			gomega.Ω(objs[0].SpaceGUID).Should(gomega.Equal("space2-guid"))
+			gomega.Ω(objs[0].SpaceName).Should(gomega.Equal("space2"))
+			gomega.Ω(objs[0].OrganizationName).Should(gomega.Equal("org2"))
+		})
+	})
+
+	ginkgo.Context("fetching shares of fetched service instances", func() {
+		ginkgo.It("skips failing instances and not shareable ones", func() {
+			server.RouteToHandler("POST", "/oauth/token", ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{"access_token": "%s", "refresh_token": "value"}`, fakeToken)))
+			server.RouteToHandler("GET", "/v3/service_instances/instance1-guid/relationships/shared_spaces", ghttp.RespondWith(http.StatusOK, `{
+				"data": [{"guid": "space2-guid"}],
+				"included": {
+					"spaces": [{"guid": "space2-guid", "name": "space2", "relationships": {"organization": {"data": {"guid": "org2-guid"}}}}],
+					"organizations": [{"guid": "org2-guid", "name": "org2"}]
+				}
+			}`))
+			server.RouteToHandler("GET", "/v3/service_instances/instance2-guid/relationships/shared_spaces", ghttp.RespondWith(http.StatusNotFound, `{
+				"errors": [{"code": 10010, "title": "CF-ResourceNotFound", "detail": "Service instance not found"}]
+			}`))
+
+			entry := models.NewCFObjects()
+			entry.ServiceOfferings["offering1-guid"] = models.ServiceOffering{ServiceOffering: resources.ServiceOffering{GUID: "offering1-guid", AllowsInstanceSharing: true}}
+			entry.ServiceOfferings["offering2-guid"] = models.ServiceOffering{ServiceOffering: resources.ServiceOffering{GUID: "offering2-guid"}}
+			entry.ServicePlans["plan1-guid"] = resources.ServicePlan{GUID: "plan1-guid", ServiceOfferingGUID: "offering1-guid"}
+			entry.ServicePlans["plan2-guid"] = resources.ServicePlan{GUID: "plan2-guid", ServiceOfferingGUID: "offering2-guid"}
+			for guid, plan := range map[string]string{"instance1-guid": "plan1-guid", "instance2-guid": "plan1-guid", "instance3-guid": "plan2-guid"} {
+				entry.ServiceInstances[guid] = resources.ServiceInstance{GUID: guid, Type: resources.ManagedServiceInstance, ServicePlanGUID: plan}
+			}
+
+			guids := shareableServiceInstances(entry)
+			gomega.Ω(guids).Should(gomega.ConsistOf("instance1-guid", "instance2-guid"))
+
+			err := (&Fetcher{}).fetchServiceInstanceShares(guids)(target, nil, entry)
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(entry.SharedSpaces).Should(gomega.HaveLen(1))
+			gomega.Ω(entry.SharedSpaces["instance1-guid"]).Should(gomega.HaveLen(1))
+			gomega.Ω(entry.SharedSpaces["instance1-guid"][0].SpaceGUID).Should(gomega.Equal("space2-guid"))
+			for _, req := range server.ReceivedRequests() {
+				gomega.Ω(req.URL.Path).ShouldNot(gomega.ContainSubstring("instance3-guid"))
+			}
+		})
+	})
+
+	ginkgo.Context("fetching ssh features of fetched spaces and applications", func() {
+		ginkgo.It("only asks applications of spaces allowing ssh and skips failing objects", func() {
+			server.RouteToHandler("POST", "/oauth/token", ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{"access_token": "%s", "refresh_token": "value"}`, fakeToken)))
+			server.RouteToHandler("GET", "/v3/spaces/space1-guid/features/ssh", ghttp.RespondWith(http.StatusOK, `{"name": "ssh", "enabled": true}`))
+			server.RouteToHandler("GET", "/v3/spaces/space2-guid/features/ssh", ghttp.RespondWith(http.StatusOK, `{"name": "ssh", "enabled": false}`))
+			server.RouteToHandler("GET", "/v3/spaces/space3-guid/features/ssh", ghttp.RespondWith(http.StatusNotFound, `{
+				"errors": [{"code": 10010, "title": "CF-ResourceNotFound", "detail": "Space not found"}]
+			}`))
+			server.RouteToHandler("GET", "/v3/apps/app1-guid/ssh_enabled", ghttp.RespondWith(http.StatusOK, `{"enabled": true, "reason": ""}`))
+			server.RouteToHandler("GET", "/v3/apps/app3-guid/ssh_enabled", ghttp.RespondWith(http.StatusNotFound, `{
+				"errors": [{"code": 10010, "title": "CF-ResourceNotFound", "detail": "App not found"}]
+			}`))
+
+			entry := models.NewCFObjects()
+			for _, guid := range []string{"space1-guid", "space2-guid", "space3-guid"} {
+				entry.Spaces[guid] = resources.Space{GUID: guid}
+			}
+			for guid, space := range map[string]string{"app1-guid": "space1-guid", "app2-guid": "space2-guid", "app3-guid": "space1-guid"} {
+				entry.Apps[guid] = models.Application{
+					GUID:          guid,
+					Relationships: resources.Relationships{constant.RelationshipTypeSpace: resources.Relationship{GUID: space}},
+				}
+			}
+
+			err := (&Fetcher{}).fetchSSHEnabled([]string{"space1-guid", "space2-guid", "space3-guid"})(target, nil, entry)
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(entry.SSHSpaces).Should(gomega.Equal(map[string]bool{"space1-guid": true, "space2-guid": false}))
+			gomega.Ω(entry.SSHApps).Should(gomega.Equal(map[string]bool{"app1-guid": true}))
+			for _, req := range server.ReceivedRequests() {
+				gomega.Ω(req.URL.Path).ShouldNot(gomega.ContainSubstring("app2-guid"))
+			}
+		})
+	})
+
+	ginkgo.Context("fetching isolation segment relationships", func() {
+		ginkgo.It("no error occurs", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/isolation_segments/segment1-guid/relationships/spaces"),
+					ghttp.RespondWith(http.StatusOK, `{"data": [{"guid": "space1-guid"}, {"guid": "space2-guid"}]}`),
+				),
+			)
+			objs, err := target.GetIsolationSegmentRelationship("segment1-guid", "spaces")
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(objs).Should(gomega.Equal([]string{"space1-guid", "space2-guid"}))
+		})
+	})
+
+	ginkgo.Context("fetching placements of fetched isolation segments", func() {
+		ginkgo.It("only keeps fetched objects and skips failing ones", func() {
+			server.RouteToHandler("POST", "/oauth/token", ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{"access_token": "%s", "refresh_token": "value"}`, fakeToken)))
+			server.RouteToHandler("GET", "/v3/isolation_segments/segment1-guid/relationships/organizations", ghttp.RespondWith(http.StatusOK, `{"data": [{"guid": "org1-guid"}, {"guid": "org2-guid"}, {"guid": "org3-guid"}]}`))
+			server.RouteToHandler("GET", "/v3/isolation_segments/segment1-guid/relationships/spaces", ghttp.RespondWith(http.StatusOK, `{"data": [{"guid": "space1-guid"}, {"guid": "space3-guid"}]}`))
+			server.RouteToHandler("GET", "/v3/isolation_segments/segment2-guid/relationships/organizations", ghttp.RespondWith(http.StatusNotFound, `{
+				"errors": [{"code": 10010, "title": "CF-ResourceNotFound", "detail": "Isolation segment not found"}]
+			}`))
+			server.RouteToHandler("GET", "/v3/organizations/org1-guid/relationships/default_isolation_segment", ghttp.RespondWith(http.StatusOK, `{"data": {"guid": "segment1-guid"}}`))
+			server.RouteToHandler("GET", "/v3/organizations/org2-guid/relationships/default_isolation_segment", ghttp.RespondWith(http.StatusNotFound, `{
+				"errors": [{"code": 10010, "title": "CF-ResourceNotFound", "detail": "Organization not found"}]
+			}`))
+
+			entry := models.NewCFObjects()
+			entry.Segments["segment1-guid"] = resources.IsolationSegment{GUID: "segment1-guid"}
+			entry.Segments["segment2-guid"] = resources.IsolationSegment{GUID: "segment2-guid"}
+			entry.Orgs["org1-guid"] = resources.Organization{GUID: "org1-guid"}
+			entry.Orgs["org2-guid"] = resources.Organization{GUID: "org2-guid"}
+			entry.Spaces["space1-guid"] = resources.Space{GUID: "space1-guid"}
+
+			err := (&Fetcher{}).fetchIsolationSegmentPlacements(target, nil, entry)
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(entry.SegmentPlacements).Should(gomega.HaveLen(1))
+			gomega.Ω(entry.SegmentPlacements["segment1-guid"].OrganizationGUIDs).Should(gomega.ConsistOf("org1-guid", "org2-guid"))
+			gomega.Ω(entry.SegmentPlacements["segment1-guid"].SpaceGUIDs).Should(gomega.Equal([]string{"space1-guid"}))
+			gomega.Ω(entry.OrgDefaultSegments).Should(gomega.Equal(map[string]string{"org1-guid": "segment1-guid"}))
+		})
+	})
+
 	ginkgo.Context("fetching tasks", func() {
 		ginkgo.It("no error occurs", func() {
 			server.AppendHandlers(
@@ -176,6 +645,9 @@ var _ = ginkgo.Describe("Extensions", func() {
 						models.Quota{
 							GUID: "guid1",
 							Name: "quota1",
+							Apps: models.QuotaApp{
+								LogRateLimit: &types.NullInt{IsSet: true, Value: 1024},
+							},
 						},
 						models.Quota{
 							GUID: "guid2",
@@ -189,6 +661,7 @@ var _ = ginkgo.Describe("Extensions", func() {
 			gomega.Ω(objs).Should(gomega.HaveLen(2))
 			gomega.Ω(objs[0].GUID).Should(gomega.Equal("guid1"))
 			gomega.Ω(objs[0].Name).Should(gomega.Equal("quota1"))
+			gomega.Ω(objs[0].Apps.LogRateLimit).Should(gomega.Equal(&types.NullInt{IsSet: true, Value: 1024}))
 			gomega.Ω(objs[1].GUID).Should(gomega.Equal("guid2"))
 			gomega.Ω(objs[1].Name).Should(gomega.Equal("quota2"))
 		})
@@ -337,5 +810,36 @@ var _ = ginkgo.Describe("Extensions", func() {
 			gomega.Ω(objs[1].Space.GUID).Should(gomega.Equal("event2-space-guid"))
 			gomega.Ω(objs[1].Org.GUID).Should(gomega.Equal("event2-org-guid"))
 		})
+
+		ginkgo.It("filters on types and follows pagination", func() {
+			token := ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/oauth/token"),
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{"access_token": "%s", "refresh_token": "value"}`, fakeToken)),
+			)
+			page1 := &ccv3.PaginatedResources{
+				ResourcesBytes: []byte(`[{"guid": "event1-guid", "type": "audit.app.create"}]`),
+			}
+			page1.Pagination.Next.HREF = server.URL() + "/v3/audit_events?page=2&per_page=1&types=audit.app.create,audit.app.delete-request"
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/audit_events", "per_page=1&types=audit.app.create,audit.app.delete-request"),
+					ghttp.RespondWith(http.StatusOK, serialize(page1)),
+				),
+				token,
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/audit_events", "page=2&per_page=1&types=audit.app.create,audit.app.delete-request"),
+					ghttp.RespondWith(http.StatusOK, serialize(&ccv3.PaginatedResources{
+						ResourcesBytes: []byte(`[{"guid": "event2-guid", "type": "audit.app.delete-request"}]`),
+					})),
+				),
+			)
+			perPage := ccv3.Query{Key: ccv3.PerPage, Values: []string{"1"}}
+			objs, err := target.GetEvents(perPage, EventTypesQuery([]string{" audit.app.create", "AUDIT.APP.DELETE-REQUEST", ""}))
+			gomega.Ω(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Ω(objs).Should(gomega.HaveLen(2))
+			gomega.Ω(objs[0].GUID).Should(gomega.Equal("event1-guid"))
+			gomega.Ω(objs[1].GUID).Should(gomega.Equal("event2-guid"))
+			gomega.Ω(objs[1].Type).Should(gomega.Equal("audit.app.delete-request"))
+		})
 	})
 })
@@ -1,6 +1,7 @@
 package fetcher
 
 import (
+	"slices"
 	"sync"
 	"time"
 
@@ -47,6 +48,17 @@ func (c *Worker) PushIf(name string, handler WorkHandler, anyArgs ...string) {
 	}
 }
 
+// PushChunksIf plans one job per chunk of at most size keys, so that the per
+// object requests of a job are spread over the worker threads
+func (c *Worker) PushChunksIf(name string, keys []string, size int, handler func([]string) WorkHandler, anyArgs ...string) {
+	if !c.filter.Any(anyArgs...) {
+		return
+	}
+	for chunk := range slices.Chunk(keys, size) {
+		c.Push(name, handler(chunk))
+	}
+}
+
 func (c *Worker) Reset() {
 	c.list = make(chan Work, 1000)
 	c.errs = make(chan error, 1000)
@@ -9,14 +9,20 @@ const (
 	ActualLRPs           = "actual_lrps"
 	Applications         = "applications"
 	Droplets             = "droplets"
+	Deployments          = "deployments"
 	Buildpacks           = "buildpacks"
+	Builds               = "builds"
 	Domains              = "domains"
 	Events               = "events"
+	FeatureFlags         = "featureflags"
 	IsolationSegments    = "isolationsegments"
 	Organizations        = "organizations"
+	Roles                = "roles"
 	Routes               = "routes"
 	SecurityGroups       = "securitygroups"
+	SecurityPosture      = "securityposture"
 	ServiceBindings      = "servicebindings"
+	ServiceBrokers       = "servicebrokers"
 	ServiceRouteBindings = "service_route_bindings"
 	ServiceInstances     = "serviceinstances"
 	ServicePlans         = "serviceplans"
@@ -24,6 +30,7 @@ const (
 	Spaces               = "spaces"
 	Stacks               = "stacks"
 	Tasks                = "tasks"
+	UsageEvents          = "usageevents"
 )
 
 var (
@@ -31,14 +38,20 @@ var (
 		ActualLRPs,
 		Applications,
 		Droplets,
+		Deployments,
 		Buildpacks,
+		Builds,
 		Domains,
 		Events,
+		FeatureFlags,
 		IsolationSegments,
 		Organizations,
+		Roles,
 		Routes,
 		SecurityGroups,
+		SecurityPosture,
 		ServiceBindings,
+		ServiceBrokers,
 		ServiceRouteBindings,
 		ServiceInstances,
 		ServicePlans,
@@ -46,6 +59,7 @@ var (
 		Spaces,
 		Stacks,
 		Tasks,
+		UsageEvents,
 	}
 )
 
@@ -70,14 +84,17 @@ func NewFilter(active ...string) (*Filter, error) {
 		activated: map[string]bool{
 			ActualLRPs:           true,
 			Applications:         true,
-			Droplets:             true,
+			Droplets:             false,
+			Deployments:          false,
 			Buildpacks:           true,
 			Domains:              true,
+			FeatureFlags:         true,
 			IsolationSegments:    true,
 			Organizations:        true,
 			Routes:               true,
 			SecurityGroups:       true,
 			ServiceBindings:      true,
+			ServiceBrokers:       true,
 			ServiceRouteBindings: true,
 			ServiceInstances:     true,
 			ServicePlans:         true,
@@ -85,7 +102,11 @@ func NewFilter(active ...string) (*Filter, error) {
 			Spaces:               true,
 			Stacks:               true,
 			Tasks:                false,
+			Builds:               false,
+			SecurityPosture:      false, // one ssh request per space and per application of ssh spaces
+			Roles:                false,
 			Events:               false,
+			UsageEvents:          false,
 		},
 	}
 
@@ -112,13 +133,19 @@ func (f *Filter) setActive(active []string) error {
 		ActualLRPs:           false,
 		Applications:         false,
 		Droplets:             false,
+		Deployments:          false,
 		Buildpacks:           false,
+		Builds:               false,
 		Domains:              false,
+		FeatureFlags:         false,
 		IsolationSegments:    false,
 		Organizations:        false,
+		Roles:                false,
 		Routes:               false,
 		SecurityGroups:       false,
+		SecurityPosture:      false,
 		ServiceBindings:      false,
+		ServiceBrokers:       false,
 		ServiceRouteBindings: false,
 		ServiceInstances:     false,
 		ServicePlans:         false,
@@ -127,6 +154,7 @@ func (f *Filter) setActive(active []string) error {
 		Stacks:               false,
 		Tasks:                false,
 		Events:               false,
+		UsageEvents:          false,
 	}
 
 	// enable only given filters
@@ -25,6 +25,7 @@ var _ = ginkgo.Describe("Filters", func() {
 			ginkgo.It("all but events are active", func() {
 				gomega.Expect(f.Enabled(filters.Applications)).To(gomega.BeTrue())
 				gomega.Expect(f.Enabled(filters.Buildpacks)).To(gomega.BeTrue())
+				gomega.Expect(f.Enabled(filters.FeatureFlags)).To(gomega.BeTrue())
 				gomega.Expect(f.Enabled(filters.IsolationSegments)).To(gomega.BeTrue())
 				gomega.Expect(f.Enabled(filters.Organizations)).To(gomega.BeTrue())
 				gomega.Expect(f.Enabled(filters.Routes)).To(gomega.BeTrue())
@@ -35,7 +36,13 @@ var _ = ginkgo.Describe("Filters", func() {
 				gomega.Expect(f.Enabled(filters.Spaces)).To(gomega.BeTrue())
 				gomega.Expect(f.Enabled(filters.Stacks)).To(gomega.BeTrue())
 				gomega.Expect(f.Enabled(filters.Tasks)).To(gomega.BeFalse())
+				gomega.Expect(f.Enabled(filters.Builds)).To(gomega.BeFalse())
+				gomega.Expect(f.Enabled(filters.Deployments)).To(gomega.BeFalse())
+				gomega.Expect(f.Enabled(filters.Droplets)).To(gomega.BeFalse())
+				gomega.Expect(f.Enabled(filters.SecurityPosture)).To(gomega.BeFalse())
+				gomega.Expect(f.Enabled(filters.Roles)).To(gomega.BeFalse())
 				gomega.Expect(f.Enabled(filters.Events)).To(gomega.BeFalse())
+				gomega.Expect(f.Enabled(filters.UsageEvents)).To(gomega.BeFalse())
 			})
 		})
 
@@ -49,6 +56,7 @@ var _ = ginkgo.Describe("Filters", func() {
 			ginkgo.It("only given filters are active", func() {
 				gomega.Expect(f.Enabled(filters.Applications)).To(gomega.BeTrue())
 				gomega.Expect(f.Enabled(filters.Buildpacks)).To(gomega.BeFalse())
+				gomega.Expect(f.Enabled(filters.FeatureFlags)).To(gomega.BeFalse())
 				gomega.Expect(f.Enabled(filters.IsolationSegments)).To(gomega.BeFalse())
 				gomega.Expect(f.Enabled(filters.Organizations)).To(gomega.BeFalse())
 				gomega.Expect(f.Enabled(filters.Routes)).To(gomega.BeFalse())
@@ -59,6 +67,8 @@ var _ = ginkgo.Describe("Filters", func() {
 				gomega.Expect(f.Enabled(filters.Spaces)).To(gomega.BeFalse())
 				gomega.Expect(f.Enabled(filters.Stacks)).To(gomega.BeTrue())
 				gomega.Expect(f.Enabled(filters.Tasks)).To(gomega.BeFalse())
+				gomega.Expect(f.Enabled(filters.Builds)).To(gomega.BeFalse())
+				gomega.Expect(f.Enabled(filters.SecurityPosture)).To(gomega.BeFalse())
 				gomega.Expect(f.Enabled(filters.Events)).To(gomega.BeFalse())
 			})
 
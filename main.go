@@ -67,13 +67,45 @@ var (
 	).Envar("CF_EXPORTER_CF_DEPLOYMENT_NAME").Required().String()
 
 	filterCollectors = kingpin.Flag(
-		"filter.collectors", "Comma separated collectors to filter (ActualLRPs,Applications,Buildpacks,Events,IsolationSegments,Organizations,Routes,SecurityGroups,ServiceBindings,ServiceInstances,ServicePlans,Services,Spaces,Stacks,Tasks,ActualLRPs). If not set, all collectors except Events and Tasks are enabled ($CF_EXPORTER_FILTER_COLLECTORS)",
+		"filter.collectors", "Comma separated collectors to filter (ActualLRPs,Applications,Buildpacks,Builds,Deployments,Droplets,Events,FeatureFlags,IsolationSegments,Organizations,Roles,Routes,SecurityGroups,SecurityPosture,ServiceBindings,ServiceBrokers,ServiceInstances,ServicePlans,Services,Spaces,Stacks,Tasks,UsageEvents). If not set, all collectors except Builds, Deployments, Droplets, Events, Roles, SecurityPosture, Tasks and UsageEvents are enabled ($CF_EXPORTER_FILTER_COLLECTORS)",
 	).Envar("CF_EXPORTER_FILTER_COLLECTORS").Default("").String()
 
 	filterTaskStates = kingpin.Flag(
 		"filter.task-states", "Comma separated task states to filter (PENDING,RUNNING,CANCELING,SUCCEEDED,FAILED). If not set, tasks are filtered by PENDING,RUNNING,CANCELING ($CF_EXPORTER_FILTER_TASK_STATES)",
 	).Envar("CF_EXPORTER_FILTER_TASK_STATES").Default("").String()
 
+	eventsTypes = kingpin.Flag(
+		"events.types", "Comma separated audit event types to fetch when the Events collector is enabled (ie: audit.app.create,audit.app.delete-request). If not set, all types are fetched ($CF_EXPORTER_EVENTS_TYPES)",
+	).Envar("CF_EXPORTER_EVENTS_TYPES").Default("").String()
+
+	eventsTargetTypes = kingpin.Flag(
+		"events.target-types", "Comma separated audit event target types to keep when the Events collector is enabled (ie: app,space,service_instance). If not set, all target types are kept ($CF_EXPORTER_EVENTS_TARGET_TYPES)",
+	).Envar("CF_EXPORTER_EVENTS_TARGET_TYPES").Default("").String()
+
+	eventsLookback = kingpin.Flag(
+		"events.lookback", "Overlap window subtracted from the previous events check time, or from now on the first scrape, to compute the creation time after which audit events are fetched when the Events collector is enabled ($CF_EXPORTER_EVENTS_LOOKBACK)",
+	).Envar("CF_EXPORTER_EVENTS_LOOKBACK").Default("15m").Duration()
+
+	eventsStateFile = kingpin.Flag(
+		"events.state-file", "Path to a file persisting the Events collector progress across restarts, so that event counters are counted once and events created while the exporter was down are not missed. If not set, the progress is kept in memory only ($CF_EXPORTER_EVENTS_STATE_FILE)",
+	).Envar("CF_EXPORTER_EVENTS_STATE_FILE").Default("").String()
+
+	usageEventsStateFile = kingpin.Flag(
+		"usage-events.state-file", "Path to a file persisting the UsageEvents collector progress across restarts, so that usage is accrued once and usage while the exporter was down is not missed. If not set, the progress is kept in memory only ($CF_EXPORTER_USAGE_EVENTS_STATE_FILE)",
+	).Envar("CF_EXPORTER_USAGE_EVENTS_STATE_FILE").Default("").String()
+
+	collectorServiceInstanceStuckThreshold = kingpin.Flag(
+		"collector.service-instance-stuck-threshold", "Duration since its start after which a service instance last operation still in progress is reported as stuck ($CF_EXPORTER_COLLECTOR_SERVICE_INSTANCE_STUCK_THRESHOLD)",
+	).Envar("CF_EXPORTER_COLLECTOR_SERVICE_INSTANCE_STUCK_THRESHOLD").Default("1h").Duration()
+
+	collectorServiceKeyMaxAgeDays = kingpin.Flag(
+		"collector.service-key-max-age-days", "Number of days after which a service key is reported as stale by the SecurityPosture collector ($CF_EXPORTER_COLLECTOR_SERVICE_KEY_MAX_AGE_DAYS)",
+	).Envar("CF_EXPORTER_COLLECTOR_SERVICE_KEY_MAX_AGE_DAYS").Default("90").Int()
+
+	collectorDeprecatedStacks = kingpin.Flag(
+		"collector.deprecated-stacks", "Comma separated stacks reported as deprecated, in addition to the stacks deprecated by the Cloud Controller ($CF_EXPORTER_COLLECTOR_DEPRECATED_STACKS)",
+	).Envar("CF_EXPORTER_COLLECTOR_DEPRECATED_STACKS").Default("").String()
+
 	metricsNamespace = kingpin.Flag(
 		"metrics.namespace", "Metrics Namespace ($CF_EXPORTER_METRICS_NAMESPACE)",
 	).Envar("CF_EXPORTER_METRICS_NAMESPACE").Default("cf").String()
@@ -210,13 +242,34 @@ func main() {
 		taskStates = strings.Split(*filterTaskStates, ",")
 	}
 	cfConfig.TaskStates = taskStates
+
+	if len(*eventsTypes) != 0 {
+		cfConfig.EventTypes = strings.Split(*eventsTypes, ",")
+	}
+	if len(*eventsTargetTypes) != 0 {
+		cfConfig.EventTargetTypes = strings.Split(*eventsTargetTypes, ",")
+	}
+	cfConfig.EventsLookback = *eventsLookback
+
 	filter, err := filters.NewFilter(active...)
 	if err != nil {
 		log.Error(err)
 		os.Exit(1)
 	}
 
-	c, err := collectors.NewCollector(*metricsNamespace, *metricsEnvironment, *cfDeploymentName, *workers, cfConfig, bbsConfig, filter)
+	collectorConfig := &collectors.Config{
+		ServiceInstanceStuckThreshold: *collectorServiceInstanceStuckThreshold,
+		ServiceKeyMaxAge:              time.Duration(*collectorServiceKeyMaxAgeDays) * 24 * time.Hour,
+		DeprecatedStacks:              collectors.SplitList(*collectorDeprecatedStacks),
+	}
+	if len(*eventsStateFile) != 0 {
+		collectorConfig.EventsStore = collectors.NewFileStore[collectors.EventsState](*eventsStateFile)
+	}
+	if len(*usageEventsStateFile) != 0 {
+		collectorConfig.UsageEventsStore = collectors.NewFileStore[collectors.UsageEventsState](*usageEventsStateFile)
+	}
+
+	c, err := collectors.NewCollector(*metricsNamespace, *metricsEnvironment, *cfDeploymentName, *workers, cfConfig, bbsConfig, filter, collectorConfig)
 	if err != nil {
 		log.Error(err)
 		os.Exit(1)
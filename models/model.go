@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"code.cloudfoundry.org/bbs/models"
@@ -11,33 +12,45 @@ import (
 )
 
 type CFObjects struct {
-	Info                 Info                                          `json:"info"`
-	Orgs                 map[string]resources.Organization             `json:"orgs"`
-	OrgQuotas            map[string]Quota                              `json:"org_quotas"`
-	Spaces               map[string]resources.Space                    `json:"spaces"`
-	SpaceQuotas          map[string]Quota                              `json:"space_quotas"`
-	Apps                 map[string]Application                        `json:"apps"`
-	Droplets             map[string]resources.Droplet                  `json:"droplets"`
-	Processes            map[string]resources.Process                  `json:"process"`
-	Tasks                map[string]Task                               `json:"tasks"`
-	Routes               map[string]resources.Route                    `json:"routes"`
-	RoutesBindings       map[string]resources.RouteBinding             `json:"route_bindings"`
-	Segments             map[string]resources.IsolationSegment         `json:"segments"`
-	ServiceInstances     map[string]resources.ServiceInstance          `json:"service_instances"`
-	SecurityGroups       map[string]resources.SecurityGroup            `json:"security_groups"`
-	Stacks               map[string]resources.Stack                    `json:"stacks"`
-	Buildpacks           map[string]resources.Buildpack                `json:"buildpacks"`
-	BuildpacksByName     map[string]resources.Buildpack                `json:"builpacks_by_name"`
-	Domains              map[string]resources.Domain                   `json:"domains"`
-	ServiceBrokers       map[string]resources.ServiceBroker            `json:"service_brokers"`
-	ServiceOfferings     map[string]resources.ServiceOffering          `json:"service_offerings"`
-	ServicePlans         map[string]resources.ServicePlan              `json:"service_plans"`
-	ServiceBindings      map[string]resources.ServiceCredentialBinding `json:"service_bindings"`
-	AppProcesses         map[string][]resources.Process                `json:"app_processes"`
-	ProcessActualLRPs    map[string][]*models.ActualLRP                `json:"process_actual_lrps"`
-	Events               map[string]Event                              `json:"events"`
-	Users                map[string]resources.User                     `json:"users"`
-	ServiceRouteBindings map[string]resources.RouteBinding             `json:"service_route_bindings"`
+	Info                 Info                                  `json:"info"`
+	Orgs                 map[string]resources.Organization     `json:"orgs"`
+	OrgQuotas            map[string]Quota                      `json:"org_quotas"`
+	Spaces               map[string]resources.Space            `json:"spaces"`
+	SpaceQuotas          map[string]Quota                      `json:"space_quotas"`
+	Apps                 map[string]Application                `json:"apps"`
+	Droplets             map[string]Droplet                    `json:"droplets"`
+	Deployments          map[string]resources.Deployment       `json:"deployments"`
+	Builds               map[string]Build                      `json:"builds"`
+	Processes            map[string]resources.Process          `json:"process"`
+	Tasks                map[string]Task                       `json:"tasks"`
+	Routes               map[string]Route                      `json:"routes"`
+	RoutesBindings       map[string]resources.RouteBinding     `json:"route_bindings"`
+	Segments             map[string]resources.IsolationSegment `json:"segments"`
+	ServiceInstances     map[string]resources.ServiceInstance  `json:"service_instances"`
+	SecurityGroups       map[string]resources.SecurityGroup    `json:"security_groups"`
+	Stacks               map[string]Stack                      `json:"stacks"`
+	StacksByName         map[string]Stack                      `json:"stacks_by_name"`
+	Buildpacks           map[string]Buildpack                  `json:"buildpacks"`
+	BuildpacksByName     map[string]resources.Buildpack        `json:"builpacks_by_name"`
+	Domains              map[string]resources.Domain           `json:"domains"`
+	FeatureFlags         map[string]FeatureFlag                `json:"feature_flags"`
+	ServiceBrokers       map[string]resources.ServiceBroker    `json:"service_brokers"`
+	ServiceOfferings     map[string]ServiceOffering            `json:"service_offerings"`
+	ServicePlans         map[string]resources.ServicePlan      `json:"service_plans"`
+	ServiceBindings      map[string]ServiceCredentialBinding   `json:"service_bindings"`
+	AppProcesses         map[string][]resources.Process        `json:"app_processes"`
+	ProcessActualLRPs    map[string][]*models.ActualLRP        `json:"process_actual_lrps"`
+	Events               map[string]Event                      `json:"events"`
+	Users                map[string]resources.User             `json:"users"`
+	Roles                map[string]resources.Role             `json:"roles"`
+	ServiceRouteBindings map[string]resources.RouteBinding     `json:"service_route_bindings"`
+	SharedSpaces         map[string][]ServiceInstanceShare     `json:"shared_spaces"`
+	SSHSpaces            map[string]bool                       `json:"ssh_spaces"`
+	SSHApps              map[string]bool                       `json:"ssh_apps"`
+	SegmentPlacements    map[string]SegmentPlacement           `json:"segment_placements"`
+	OrgDefaultSegments   map[string]string                     `json:"org_default_segments"`
+	AppUsageEvents       []AppUsageEvent                       `json:"app_usage_events"`
+	ServiceUsageEvents   []ServiceUsageEvent                   `json:"service_usage_events"`
 	Took                 float64
 	Error                error
 }
@@ -47,6 +60,7 @@ type QuotaApp struct {
 	InstanceMemory    *types.NullInt `json:"per_process_memory_in_mb,omitempty"`
 	TotalAppInstances *types.NullInt `json:"total_instances,omitempty"`
 	PerAppTasks       *types.NullInt `json:"per_app_tasks,omitempty"`
+	LogRateLimit      *types.NullInt `json:"log_rate_limit_in_bytes_per_second,omitempty"`
 }
 
 type QuotaService struct {
@@ -96,6 +110,137 @@ type Application struct {
 	UpdatedAt     string                    `json:"updated_at,omitempty"`
 }
 
+type Droplet struct {
+	GUID          string                       `json:"guid,omitempty"`
+	State         constant.DropletState        `json:"state,omitempty"`
+	Lifecycle     resources.DropletLifecycle   `json:"lifecycle,omitempty"`
+	Buildpacks    []resources.DropletBuildpack `json:"buildpacks,omitempty"`
+	Stack         string                       `json:"stack,omitempty"`
+	Image         string                       `json:"image,omitempty"`
+	Relationships resources.Relationships      `json:"relationships,omitempty"`
+	CreatedAt     time.Time                    `json:"created_at,omitempty"`
+	UpdatedAt     time.Time                    `json:"updated_at,omitempty"`
+}
+
+type Build struct {
+	GUID          string                  `json:"guid,omitempty"`
+	State         constant.BuildState     `json:"state,omitempty"`
+	Error         string                  `json:"error,omitempty"`
+	Lifecycle     Lifecycle               `json:"lifecycle,omitempty"`
+	Relationships resources.Relationships `json:"relationships,omitempty"`
+	CreatedAt     time.Time               `json:"created_at,omitempty"`
+	UpdatedAt     time.Time               `json:"updated_at,omitempty"`
+}
+
+type RouteDestination struct {
+	GUID     string                        `json:"guid,omitempty"`
+	App      resources.RouteDestinationApp `json:"app,omitempty"`
+	Weight   types.NullInt                 `json:"weight,omitempty"`
+	Port     int                           `json:"port,omitempty"`
+	Protocol string                        `json:"protocol,omitempty"`
+}
+
+// unmarshalExtended decodes a cf cli resource then the fields the exporter
+// adds to it, the resource UnmarshalJSON promoted to the extending type would
+// otherwise only decode the resource fields. Resources without UnmarshalJSON
+// are simply embedded next to the added fields
+func unmarshalExtended(data []byte, resource json.Unmarshaler, extension any) error {
+	if err := resource.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, extension)
+}
+
+// Route adds the destinations weight to the cf cli resource, its destinations
+// shadow the resource ones which have no weight
+type Route struct {
+	resources.Route
+	Destinations []RouteDestination `json:"destinations,omitempty"`
+}
+
+func (r *Route) UnmarshalJSON(data []byte) error {
+	return unmarshalExtended(data, &r.Route, &struct {
+		Destinations *[]RouteDestination `json:"destinations,omitempty"`
+	}{&r.Destinations})
+}
+
+// ServiceOffering adds the availability and the broker catalog features to
+// the cf cli resource
+type ServiceOffering struct {
+	resources.ServiceOffering
+	serviceOfferingExtension
+}
+
+type serviceOfferingExtension struct {
+	Available     bool `json:"available"`
+	BrokerCatalog struct {
+		Features struct {
+			Bindable bool `json:"bindable"`
+		} `json:"features"`
+	} `json:"broker_catalog"`
+}
+
+func (o *ServiceOffering) UnmarshalJSON(data []byte) error {
+	return unmarshalExtended(data, &o.ServiceOffering, &o.serviceOfferingExtension)
+}
+
+// Buildpack adds the creation and last update times to the cf cli resource
+type Buildpack struct {
+	resources.Buildpack
+	buildpackExtension
+}
+
+type buildpackExtension struct {
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+func (b *Buildpack) UnmarshalJSON(data []byte) error {
+	return unmarshalExtended(data, &b.Buildpack, &b.buildpackExtension)
+}
+
+// FeatureFlag adds the last update time to the cf cli resource, left to zero
+// when the flag has never been changed
+type FeatureFlag struct {
+	resources.FeatureFlag
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// Stack adds the default flag to the cf cli resource
+type Stack struct {
+	resources.Stack
+	Default bool `json:"default"`
+}
+
+// ServiceCredentialBinding adds the last update time to the cf cli resource
+type ServiceCredentialBinding struct {
+	resources.ServiceCredentialBinding
+	serviceCredentialBindingExtension
+}
+
+type serviceCredentialBindingExtension struct {
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+func (b *ServiceCredentialBinding) UnmarshalJSON(data []byte) error {
+	return unmarshalExtended(data, &b.ServiceCredentialBinding, &b.serviceCredentialBindingExtension)
+}
+
+// ServiceInstanceShare is a space a service instance is shared to
+type ServiceInstanceShare struct {
+	ServiceInstanceGUID string `json:"service_instance_guid,omitempty"`
+	SpaceGUID           string `json:"space_guid,omitempty"`
+	SpaceName           string `json:"space_name,omitempty"`
+	OrganizationName    string `json:"organization_name,omitempty"`
+}
+
+// SegmentPlacement holds the organizations entitled to an isolation segment
+// and the spaces assigned to it
+type SegmentPlacement struct {
+	OrganizationGUIDs []string `json:"organization_guids,omitempty"`
+	SpaceGUIDs        []string `json:"space_guids,omitempty"`
+}
+
 type Task struct {
 	GUID          string                  `json:"guid,omitempty"`
 	State         constant.TaskState      `json:"state,omitempty"`
@@ -151,6 +296,51 @@ type Event struct {
 	Org       EventOrg               `json:"organization,omitempty"`
 }
 
+// UsageEventResource references the resource a usage event applies to
+type UsageEventResource struct {
+	GUID string `json:"guid,omitempty"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+type UsageEventState struct {
+	Current  string `json:"current,omitempty"`
+	Previous string `json:"previous,omitempty"`
+}
+
+type UsageEventValue struct {
+	Current  int `json:"current,omitempty"`
+	Previous int `json:"previous,omitempty"`
+}
+
+// AppUsageEvent is emitted by the Cloud Controller each time a process or a
+// task starts, stops or is scaled
+type AppUsageEvent struct {
+	GUID                  string             `json:"guid,omitempty"`
+	CreatedAt             time.Time          `json:"created_at,omitempty"`
+	State                 UsageEventState    `json:"state,omitempty"`
+	App                   UsageEventResource `json:"app,omitempty"`
+	Process               UsageEventResource `json:"process,omitempty"`
+	Task                  UsageEventResource `json:"task,omitempty"`
+	Space                 UsageEventResource `json:"space,omitempty"`
+	Org                   UsageEventResource `json:"organization,omitempty"`
+	MemoryInMBPerInstance UsageEventValue    `json:"memory_in_mb_per_instance,omitempty"`
+	InstanceCount         UsageEventValue    `json:"instance_count,omitempty"`
+}
+
+// ServiceUsageEvent is emitted by the Cloud Controller each time a service
+// instance is created, updated or deleted
+type ServiceUsageEvent struct {
+	GUID            string             `json:"guid,omitempty"`
+	CreatedAt       time.Time          `json:"created_at,omitempty"`
+	State           string             `json:"state,omitempty"`
+	Space           UsageEventResource `json:"space,omitempty"`
+	Org             UsageEventResource `json:"organization,omitempty"`
+	ServiceInstance UsageEventResource `json:"service_instance,omitempty"`
+	ServicePlan     UsageEventResource `json:"service_plan,omitempty"`
+	ServiceOffering UsageEventResource `json:"service_offering,omitempty"`
+}
+
 func NewCFObjects() *CFObjects {
 	return &CFObjects{
 		Info:                 Info{},
@@ -159,26 +349,38 @@ func NewCFObjects() *CFObjects {
 		Spaces:               map[string]resources.Space{},
 		SpaceQuotas:          map[string]Quota{},
 		Apps:                 map[string]Application{},
-		Droplets:             map[string]resources.Droplet{},
+		Droplets:             map[string]Droplet{},
+		Deployments:          map[string]resources.Deployment{},
+		Builds:               map[string]Build{},
 		Processes:            map[string]resources.Process{},
 		Tasks:                map[string]Task{},
-		Routes:               map[string]resources.Route{},
+		Routes:               map[string]Route{},
 		RoutesBindings:       map[string]resources.RouteBinding{},
 		Segments:             map[string]resources.IsolationSegment{},
 		ServiceInstances:     map[string]resources.ServiceInstance{},
 		SecurityGroups:       map[string]resources.SecurityGroup{},
-		Stacks:               map[string]resources.Stack{},
-		Buildpacks:           map[string]resources.Buildpack{},
+		Stacks:               map[string]Stack{},
+		StacksByName:         map[string]Stack{},
+		Buildpacks:           map[string]Buildpack{},
 		Domains:              map[string]resources.Domain{},
+		FeatureFlags:         map[string]FeatureFlag{},
 		ServiceBrokers:       map[string]resources.ServiceBroker{},
-		ServiceOfferings:     map[string]resources.ServiceOffering{},
+		ServiceOfferings:     map[string]ServiceOffering{},
 		ServicePlans:         map[string]resources.ServicePlan{},
-		ServiceBindings:      map[string]resources.ServiceCredentialBinding{},
+		ServiceBindings:      map[string]ServiceCredentialBinding{},
 		AppProcesses:         map[string][]resources.Process{},
 		ProcessActualLRPs:    map[string][]*models.ActualLRP{},
 		Users:                map[string]resources.User{},
+		Roles:                map[string]resources.Role{},
 		Events:               map[string]Event{},
 		ServiceRouteBindings: map[string]resources.RouteBinding{},
+		SharedSpaces:         map[string][]ServiceInstanceShare{},
+		SSHSpaces:            map[string]bool{},
+		SSHApps:              map[string]bool{},
+		SegmentPlacements:    map[string]SegmentPlacement{},
+		OrgDefaultSegments:   map[string]string{},
+		AppUsageEvents:       []AppUsageEvent{},
+		ServiceUsageEvents:   []ServiceUsageEvent{},
 		Took:                 0,
 		Error:                nil,
 	}